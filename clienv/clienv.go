@@ -1,6 +1,7 @@
 package clienv
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"regexp"
@@ -22,6 +23,16 @@ type CliEnv struct {
 	domain      string
 	nhclient    *nhostclient.Client
 	projectName string
+	// yes answers yes to all confirmations
+	yes bool
+	// nonInteractive fails instead of asking questions
+	nonInteractive bool
+	// output is the format of the results of commands
+	output string
+	// contextName is the name of the context in use
+	contextName string
+	// workspace is the workspace to default to when linking projects
+	workspace string
 }
 
 func New(
@@ -32,33 +43,83 @@ func New(
 	projectName string,
 ) *CliEnv {
 	return &CliEnv{
-		stdout:      stdout,
-		stderr:      stderr,
-		Path:        path,
-		domain:      domain,
-		nhclient:    nil,
-		projectName: projectName,
+		stdout:         stdout,
+		stderr:         stderr,
+		Path:           path,
+		domain:         domain,
+		nhclient:       nil,
+		projectName:    projectName,
+		yes:            false,
+		nonInteractive: !stdinIsTerminal(),
+		output:         OutputTable,
+		contextName:    DefaultContext,
+		workspace:      "",
 	}
 }
 
+// resolveContext returns the context selected with --context or, if not set,
+// the current one. The domain set with --domain takes precedence over the
+// context's.
+func resolveContext(cCtx *cli.Context) (string, Context, error) {
+	contexts, err := LoadContexts()
+	if err != nil {
+		return "", Context{}, err
+	}
+
+	name := contexts.Current
+	if cCtx.IsSet(flagContext) {
+		name = cCtx.String(flagContext)
+	}
+	context, err := contexts.Get(name)
+	switch {
+	case err != nil && !cCtx.IsSet(flagContext):
+		return "", Context{}, fmt.Errorf( //nolint:goerr113
+			"current context %s not found, select another one with `nhost --context %s context use <name>`",
+			name, DefaultContext,
+		)
+	case err != nil:
+		return "", Context{}, err
+	}
+
+	if cCtx.IsSet(flagDomain) {
+		context.Domain = cCtx.String(flagDomain)
+	}
+	return name, context, nil
+}
+
 func FromCLI(cCtx *cli.Context) *CliEnv {
 	cwd, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
+	disableColorsIfNotTerminal(cCtx.App.Writer)
+
+	contextName, context, err := resolveContext(cCtx)
+	if err != nil {
+		// the context is checked by ApplySettings before running any command
+		panic(err)
+	}
+	path := NewPathStructure(
+		cwd,
+		cCtx.String(flagRootFolder),
+		cCtx.String(flagDotNhostFolder),
+		cCtx.String(flagDataFolder),
+		cCtx.String(flagNhostFolder),
+	)
+	path.authFile = ContextAuthFile(contextName)
+
 	return &CliEnv{
-		stdout: cCtx.App.Writer,
-		stderr: cCtx.App.ErrWriter,
-		Path: NewPathStructure(
-			cwd,
-			cCtx.String(flagRootFolder),
-			cCtx.String(flagDotNhostFolder),
-			cCtx.String(flagDataFolder),
-			cCtx.String(flagNhostFolder),
-		),
-		domain:      cCtx.String(flagDomain),
-		projectName: sanitizeName(cCtx.String(flagProjectName)),
-		nhclient:    nil,
+		stdout:         cCtx.App.Writer,
+		stderr:         cCtx.App.ErrWriter,
+		Path:           path,
+		domain:         context.Domain,
+		projectName:    sanitizeName(cCtx.String(flagProjectName)),
+		nhclient:       nil,
+		yes:            cCtx.Bool(flagYes),
+		nonInteractive: cCtx.Bool(flagNonInteractive) || !stdinIsTerminal() || completing(),
+		output:         cCtx.String(flagOutput),
+		contextName:    contextName,
+		workspace:      context.Workspace,
 	}
 }
 
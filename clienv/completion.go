@@ -0,0 +1,193 @@
+package clienv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nhost/cli/nhostclient/credentials"
+	"github.com/nhost/cli/nhostclient/graphql"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	appsCacheTTL      = time.Hour
+	completionTimeout = 5 * time.Second
+)
+
+// Completer returns the values to suggest when completing an argument or the
+// value of a flag. Completers run on every key press so they must not ask for
+// input and should fail silently returning no suggestions.
+type Completer func(cCtx *cli.Context) []string
+
+// Complete returns a cli.BashCompleteFunc that suggests values for the given
+// flags when they are the previous word, the values returned by args for
+// positional arguments and falls back to the default completion of urfave/cli
+// otherwise.
+func Complete(flags map[string]Completer, args Completer) cli.BashCompleteFunc {
+	return func(cCtx *cli.Context) {
+		// ApplySettings doesn't run when completing
+		if _, _, err := resolveContext(cCtx); err != nil {
+			return
+		}
+
+		if len(os.Args) > 2 { //nolint:gomnd
+			// the last argument is --generate-bash-completion
+			prev := os.Args[len(os.Args)-2]
+			if completer, ok := flags[strings.TrimLeft(prev, "-")]; ok &&
+				strings.HasPrefix(prev, "-") {
+				printSuggestions(cCtx, completer(cCtx))
+				return
+			}
+			if strings.HasPrefix(prev, "-") || args == nil {
+				cli.DefaultCompleteWithFlags(cCtx.Command)(cCtx)
+				return
+			}
+		}
+
+		if args != nil {
+			printSuggestions(cCtx, args(cCtx))
+		}
+	}
+}
+
+// CompleteSubdomain returns a cli.BashCompleteFunc for commands with a
+// --subdomain flag.
+func CompleteSubdomain() cli.BashCompleteFunc {
+	return Complete(map[string]Completer{flagSubdomain: Subdomains}, nil)
+}
+
+// completing returns true if the CLI was invoked to complete a command line.
+func completing() bool {
+	return len(os.Args) > 0 && os.Args[len(os.Args)-1] == "--generate-bash-completion"
+}
+
+func printSuggestions(cCtx *cli.Context, suggestions []string) {
+	for _, s := range suggestions {
+		fmt.Fprintln(cCtx.App.Writer, s)
+	}
+}
+
+type appsCache struct {
+	UpdatedAt time.Time                                    `json:"updatedAt"`
+	Apps      []*graphql.GetWorkspacesApps_Workspaces_Apps `json:"apps"`
+}
+
+func (ce *CliEnv) appsCacheFile() string {
+	if ce.contextName == DefaultContext {
+		return filepath.Join(PathStateHome(), "apps-cache.json")
+	}
+	return filepath.Join(PathStateHome(), "apps-cache-"+ce.contextName+".json")
+}
+
+// CompletionSession is like LoadSession but suitable for completion: it never
+// asks for credentials, rotates the PAT or prints anything. If the user isn't
+// logged in it fails.
+func (ce *CliEnv) CompletionSession(ctx context.Context) (credentials.Session, error) {
+	var creds credentials.Credentials
+	if err := UnmarshalFile(ce.Path.AuthFile(), &creds, json.Unmarshal); err != nil {
+		return credentials.Session{}, fmt.Errorf("not logged in: %w", err)
+	}
+
+	session, err := ce.GetNhostClient().LoginPAT(ctx, creds.PersonalAccessToken)
+	if err != nil {
+		return credentials.Session{}, fmt.Errorf("failed to login: %w", err)
+	}
+	return session, nil
+}
+
+// cachedApps returns the apps the user has access to. They are cached for an
+// hour to keep completion fast. It never asks for credentials, if the user
+// isn't logged in it fails.
+func (ce *CliEnv) cachedApps(ctx context.Context) ([]*graphql.GetWorkspacesApps_Workspaces_Apps, error) {
+	var cache appsCache
+	if err := UnmarshalFile(ce.appsCacheFile(), &cache, json.Unmarshal); err == nil &&
+		time.Since(cache.UpdatedAt) < appsCacheTTL {
+		return cache.Apps, nil
+	}
+
+	session, err := ce.CompletionSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := ce.GetNhostClient().GetWorkspacesApps(
+		ctx,
+		graphql.WithAccessToken(session.Session.AccessToken),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+
+	cache = appsCache{UpdatedAt: time.Now(), Apps: nil}
+	for _, workspace := range workspaces.GetWorkspaces() {
+		cache.Apps = append(cache.Apps, workspace.GetApps()...)
+	}
+
+	if err := os.MkdirAll(PathStateHome(), 0o755); err != nil { //nolint:gomnd
+		return nil, fmt.Errorf("failed to create state folder: %w", err)
+	}
+	if err := MarshalFile(cache, ce.appsCacheFile(), json.Marshal); err != nil {
+		return nil, fmt.Errorf("failed to write apps cache: %w", err)
+	}
+
+	return cache.Apps, nil
+}
+
+// Subdomains suggests the subdomains of the cloud projects the user has
+// access to and the names of the overlays in nhost/overlays.
+func Subdomains(cCtx *cli.Context) []string {
+	ce := FromCLI(cCtx)
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, completionTimeout)
+	defer cancel()
+
+	seen := map[string]struct{}{}
+	apps, _ := ce.cachedApps(ctx)
+	for _, app := range apps {
+		seen[app.Subdomain] = struct{}{}
+	}
+
+	overlays, _ := filepath.Glob(filepath.Join(ce.Path.OverlaysFolder(), "*.json"))
+	for _, overlay := range overlays {
+		seen[strings.TrimSuffix(filepath.Base(overlay), ".json")] = struct{}{}
+	}
+
+	subdomains := make([]string, 0, len(seen))
+	for s := range seen {
+		subdomains = append(subdomains, s)
+	}
+	sort.Strings(subdomains)
+	return subdomains
+}
+
+// CompletionApp returns the cloud project selected with --subdomain or the
+// linked one without asking the user to link a project or log in.
+func (ce *CliEnv) CompletionApp(
+	ctx context.Context,
+	subdomain string,
+) (*graphql.GetWorkspacesApps_Workspaces_Apps, error) {
+	if subdomain == "" {
+		var app *graphql.GetWorkspacesApps_Workspaces_Apps
+		if err := UnmarshalFile(ce.Path.ProjectFile(), &app, json.Unmarshal); err != nil {
+			return nil, err
+		}
+		return app, nil
+	}
+
+	apps, err := ce.cachedApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps {
+		if app.Subdomain == subdomain {
+			return app, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to find app with subdomain: %s", subdomain) //nolint:goerr113
+}
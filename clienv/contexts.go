@@ -0,0 +1,119 @@
+package clienv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+const (
+	DefaultContext = "default"
+	defaultDomain  = "nhost.run"
+)
+
+var contextNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Context is a target cloud, the credentials to use with it and the
+// workspace to default to when linking projects.
+type Context struct {
+	Domain    string `json:"domain"`
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// Contexts are the contexts configured by the user, the default context is
+// always available even if it isn't in the file.
+type Contexts struct {
+	Current  string             `json:"current"`
+	Contexts map[string]Context `json:"contexts"`
+}
+
+func contextsFile() string {
+	return filepath.Join(PathStateHome(), "contexts.json")
+}
+
+// ContextAuthFile returns the file with the credentials of the context name.
+// The default context uses the file used before contexts existed.
+func ContextAuthFile(name string) string {
+	if name == DefaultContext {
+		return filepath.Join(PathStateHome(), "auth.json")
+	}
+	return filepath.Join(PathStateHome(), "auth-"+name+".json")
+}
+
+// ValidateContextName returns an error if name can't be used as the name of
+// a context.
+func ValidateContextName(name string) error {
+	if !contextNameRe.MatchString(name) {
+		return fmt.Errorf( //nolint:goerr113
+			"invalid context name %q, only letters, numbers, - and _ are allowed", name,
+		)
+	}
+	return nil
+}
+
+// LoadContexts reads the contexts configured by the user.
+func LoadContexts() (*Contexts, error) {
+	contexts := &Contexts{Current: DefaultContext, Contexts: map[string]Context{}}
+	if err := UnmarshalFile(contextsFile(), contexts, json.Unmarshal); err != nil &&
+		!errors.Is(err, os.ErrNotExist) && !errors.Is(err, ErrNoContent) {
+		return nil, fmt.Errorf("failed to read contexts: %w", err)
+	}
+
+	if contexts.Contexts == nil {
+		contexts.Contexts = map[string]Context{}
+	}
+	if _, ok := contexts.Contexts[DefaultContext]; !ok {
+		contexts.Contexts[DefaultContext] = Context{Domain: defaultDomain, Workspace: ""}
+	}
+	if contexts.Current == "" {
+		contexts.Current = DefaultContext
+	}
+	return contexts, nil
+}
+
+// Save writes the contexts.
+func (c *Contexts) Save() error {
+	if err := os.MkdirAll(PathStateHome(), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create state folder: %w", err)
+	}
+	if err := MarshalFile(c, contextsFile(), json.Marshal); err != nil {
+		return fmt.Errorf("failed to write contexts: %w", err)
+	}
+	return nil
+}
+
+// Names returns the names of the contexts sorted.
+func (c *Contexts) Names() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the context name.
+func (c *Contexts) Get(name string) (Context, error) {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return Context{}, fmt.Errorf( //nolint:goerr113
+			"context %s not found, run `nhost context list` to see the available contexts", name,
+		)
+	}
+	return ctx, nil
+}
+
+// ContextName returns the name of the context the CLI is using.
+func (ce *CliEnv) ContextName() string {
+	return ce.contextName
+}
+
+// Workspace returns the workspace to default to when linking projects, if
+// any.
+func (ce *CliEnv) Workspace() string {
+	return ce.workspace
+}
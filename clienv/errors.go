@@ -0,0 +1,271 @@
+package clienv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/nhost/cli/nhostclient"
+	"github.com/urfave/cli/v2"
+)
+
+// Exit codes returned by the CLI. Any error not listed here exits with
+// ExitCodeGeneric, commands that run other programs, like `nhost test`, exit
+// with the program's exit code.
+const (
+	ExitCodeGeneric           = 1
+	ExitCodeAuthFailed        = 3
+	ExitCodeNotLinked         = 4
+	ExitCodeConfigInvalid     = 5
+	ExitCodeDockerUnavailable = 6
+	ExitCodeNetwork           = 7
+	ExitCodePermissionDenied  = 8
+	ExitCodeCommandNotFound   = 9
+)
+
+// ErrorKind describes a class of errors the CLI knows how to explain.
+type ErrorKind struct {
+	Code    int
+	Message string
+	Hint    string
+}
+
+//nolint:gochecknoglobals
+var (
+	KindAuthFailed = ErrorKind{
+		Code:    ExitCodeAuthFailed,
+		Message: "authentication failed, your credentials are invalid or have expired",
+		Hint:    "run `nhost login`",
+	}
+	KindNotLinked = ErrorKind{
+		Code:    ExitCodeNotLinked,
+		Message: "project is not linked to a cloud project",
+		Hint:    "run `nhost link` or pass --subdomain",
+	}
+	KindConfigInvalid = ErrorKind{
+		Code:    ExitCodeConfigInvalid,
+		Message: "configuration is invalid",
+		Hint:    "fix nhost/nhost.toml and run `nhost config validate`",
+	}
+	KindDockerUnavailable = ErrorKind{
+		Code:    ExitCodeDockerUnavailable,
+		Message: "docker is not available",
+		Hint:    "make sure docker is installed and running",
+	}
+	KindNetwork = ErrorKind{
+		Code:    ExitCodeNetwork,
+		Message: "failed to reach the Nhost API",
+		Hint:    "check your internet connection and try again",
+	}
+	KindPermissionDenied = ErrorKind{
+		Code:    ExitCodePermissionDenied,
+		Message: "permission denied",
+		Hint:    "make sure your account has access to the project",
+	}
+	KindFilePermissionDenied = ErrorKind{
+		Code:    ExitCodePermissionDenied,
+		Message: "permission denied",
+		Hint:    "check the permissions of the file or run from a folder you own",
+	}
+)
+
+// Error is an error of a known kind.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// NewError returns err as an error of the given kind.
+func NewError(kind ErrorKind, err error) *Error {
+	return &Error{Kind: kind, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Kind.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+const (
+	graphqlCodeInvalidJWT      = "invalid-jwt"
+	graphqlCodeAccessDenied    = "access-denied"
+	graphqlCodePermissionError = "permission-error"
+)
+
+func graphqlErrorKind(err *clientv2.ErrorResponse) (ErrorKind, bool) {
+	if err.NetworkError != nil {
+		switch err.NetworkError.Code {
+		case http.StatusUnauthorized:
+			return KindAuthFailed, true
+		case http.StatusForbidden:
+			return KindPermissionDenied, true
+		}
+	}
+
+	if err.GqlErrors != nil {
+		for _, e := range *err.GqlErrors {
+			code, _ := e.Extensions["code"].(string)
+			switch code {
+			case graphqlCodeInvalidJWT:
+				return KindAuthFailed, true
+			case graphqlCodeAccessDenied, graphqlCodePermissionError:
+				return KindPermissionDenied, true
+			}
+		}
+	}
+
+	return ErrorKind{}, false //nolint:exhaustruct
+}
+
+// Classify returns err as an *Error if it is or was caused by an error of a
+// known kind.
+func Classify(err error) (*Error, bool) {
+	var (
+		cliErr     *Error
+		apiErr     *nhostclient.APIError
+		graphqlErr *clientv2.ErrorResponse
+		netErr     *nhostclient.NetworkError
+	)
+
+	switch {
+	case errors.As(err, &cliErr):
+		return cliErr, true
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+		return NewError(KindAuthFailed, err), true
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+		return NewError(KindPermissionDenied, err), true
+	case errors.As(err, &graphqlErr):
+		if kind, ok := graphqlErrorKind(graphqlErr); ok {
+			return NewError(kind, err), true
+		}
+	case dockercompose.DockerUnavailable(err):
+		return NewError(KindDockerUnavailable, err), true
+	case errors.Is(err, os.ErrPermission):
+		return NewError(KindFilePermissionDenied, err), true
+	case errors.As(err, &netErr) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded):
+		return NewError(KindNetwork, err), true
+	}
+
+	return nil, false
+}
+
+// rootCause returns the innermost error in err's chain.
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// describe returns a one-line description of err. GraphQL errors are reduced
+// to their messages.
+func describe(err error) string {
+	var graphqlErr *clientv2.ErrorResponse
+	if !errors.As(err, &graphqlErr) {
+		return strings.TrimSpace(rootCause(err).Error())
+	}
+
+	var msgs []string
+	if graphqlErr.NetworkError != nil {
+		msgs = append(msgs, graphqlErr.NetworkError.Message)
+	}
+	if graphqlErr.GqlErrors != nil {
+		for _, e := range *graphqlErr.GqlErrors {
+			msgs = append(msgs, e.Message)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// details returns everything known about err for --debug.
+func details(err error) string {
+	lines := []string{err.Error()}
+
+	var graphqlErr *clientv2.ErrorResponse
+	if errors.As(err, &graphqlErr) {
+		if b, jerr := json.MarshalIndent(graphqlErr, "", "  "); jerr == nil {
+			lines = append(lines, "GraphQL response:", string(b))
+		}
+	}
+
+	var cmdErr *dockercompose.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Stderr != "" {
+		lines = append(lines, "Command output:", strings.TrimSpace(cmdErr.Stderr))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// PrintError writes err to w as a one-line message followed by a hint, if
+// the kind of error is known, and returns the exit code to use. With debug
+// the full error is printed as well.
+func PrintError(w io.Writer, err error, debug bool) int {
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		if err.Error() != "" {
+			fmt.Fprintln(w, warn("Error: "+err.Error()))
+		}
+		return exitCoder.ExitCode()
+	}
+
+	code := ExitCodeGeneric
+	msg := err.Error()
+	var graphqlErr *clientv2.ErrorResponse
+	if errors.As(err, &graphqlErr) {
+		msg = describe(err)
+	}
+	hint := ""
+	if cliErr, ok := Classify(err); ok {
+		code = cliErr.Kind.Code
+		msg = cliErr.Kind.Message + ": " + describe(err)
+		hint = cliErr.Kind.Hint
+	}
+
+	fmt.Fprintln(w, warn("Error: "+msg))
+	if hint != "" {
+		fmt.Fprintln(w, info("Hint: "+hint))
+	}
+	if debug {
+		fmt.Fprintln(w, "Details:")
+		fmt.Fprintln(w, details(err))
+	}
+	return code
+}
+
+// DebugRequested returns true if --debug is in args or NHOST_DEBUG is set. It
+// is meant for errors returned before the flags are parsed.
+func DebugRequested(args []string) bool {
+	if value, ok := flagArg(args, []string{flagDebug}); ok {
+		if value == "" {
+			return true
+		}
+		debug, _ := strconv.ParseBool(value)
+		return debug
+	}
+
+	debug, _ := strconv.ParseBool(os.Getenv("NHOST_DEBUG"))
+	return debug
+}
+
+// ExitErrHandler is the cli.ExitErrHandlerFunc of the CLI. It prints errors
+// returned by commands with PrintError and exits with the corresponding code.
+func ExitErrHandler(cCtx *cli.Context, err error) {
+	if err == nil {
+		return
+	}
+	cli.OsExiter(PrintError(cCtx.App.ErrWriter, err, cCtx.Bool(flagDebug)))
+}
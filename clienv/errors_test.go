@@ -0,0 +1,322 @@
+package clienv_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-cmp/cmp"
+	"github.com/muesli/termenv"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/nhost/cli/nhostclient"
+	"github.com/urfave/cli/v2"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestPrintError(t *testing.T) { //nolint:funlen,maintidx
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	gqlErrors := func(code string) error {
+		return fmt.Errorf("failed to get apps: %w", &clientv2.ErrorResponse{
+			NetworkError: nil,
+			GqlErrors: &gqlerror.List{
+				{Message: "not allowed", Extensions: map[string]any{"code": code}},
+			},
+		})
+	}
+
+	cases := []struct {
+		name     string
+		err      error
+		debug    bool
+		kind     *clienv.ErrorKind
+		code     int
+		expected string
+	}{
+		{
+			name:     "unknown error",
+			err:      errors.New("failed to read file: boom"), //nolint:goerr113
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: failed to read file: boom\n",
+		},
+		{
+			name:     "exit coder",
+			err:      cli.Exit("Command exited with code 2", 2), //nolint:gomnd
+			debug:    false,
+			kind:     nil,
+			code:     2, //nolint:gomnd
+			expected: "Error: Command exited with code 2\n",
+		},
+		{
+			name:     "silent exit coder",
+			err:      cli.Exit("", 3), //nolint:gomnd
+			debug:    false,
+			kind:     nil,
+			code:     3, //nolint:gomnd
+			expected: "",
+		},
+		{
+			name: "not linked",
+			err: fmt.Errorf(
+				"failed to get app: %w",
+				clienv.NewError(clienv.KindNotLinked, errors.New("no .nhost/nhost.json")), //nolint:goerr113
+			),
+			debug: false,
+			kind:  &clienv.KindNotLinked,
+			code:  clienv.ExitCodeNotLinked,
+			expected: "Error: project is not linked to a cloud project: no .nhost/nhost.json\n" +
+				"Hint: run `nhost link` or pass --subdomain\n",
+		},
+		{
+			name: "config invalid",
+			err: clienv.NewError(
+				clienv.KindConfigInvalid, errors.New("hasura.version is required"), //nolint:goerr113
+			),
+			debug: false,
+			kind:  &clienv.KindConfigInvalid,
+			code:  clienv.ExitCodeConfigInvalid,
+			expected: "Error: configuration is invalid: hasura.version is required\n" +
+				"Hint: fix nhost/nhost.toml and run `nhost config validate`\n",
+		},
+		{
+			name: "api unauthorized",
+			err: fmt.Errorf("failed to login: %w", &nhostclient.APIError{
+				StatusCode: http.StatusUnauthorized, Message: "invalid token",
+			}),
+			debug: false,
+			kind:  &clienv.KindAuthFailed,
+			code:  clienv.ExitCodeAuthFailed,
+			expected: "Error: authentication failed, your credentials are invalid or have expired: " +
+				"unexpected status code: 401, message: invalid token\n" +
+				"Hint: run `nhost login`\n",
+		},
+		{
+			name: "api forbidden",
+			err: &nhostclient.APIError{
+				StatusCode: http.StatusForbidden, Message: "forbidden",
+			},
+			debug: false,
+			kind:  &clienv.KindPermissionDenied,
+			code:  clienv.ExitCodePermissionDenied,
+			expected: "Error: permission denied: unexpected status code: 403, message: forbidden\n" +
+				"Hint: make sure your account has access to the project\n",
+		},
+		{
+			name: "api server error",
+			err: &nhostclient.APIError{
+				StatusCode: http.StatusInternalServerError, Message: "oops",
+			},
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: unexpected status code: 500, message: oops\n",
+		},
+		{
+			name: "graphql unauthorized",
+			err: &clientv2.ErrorResponse{
+				NetworkError: &clientv2.HTTPError{Code: http.StatusUnauthorized, Message: "expired"},
+				GqlErrors:    nil,
+			},
+			debug: false,
+			kind:  &clienv.KindAuthFailed,
+			code:  clienv.ExitCodeAuthFailed,
+			expected: "Error: authentication failed, your credentials are invalid or have expired: expired\n" +
+				"Hint: run `nhost login`\n",
+		},
+		{
+			name:  "graphql invalid jwt",
+			err:   gqlErrors("invalid-jwt"),
+			debug: false,
+			kind:  &clienv.KindAuthFailed,
+			code:  clienv.ExitCodeAuthFailed,
+			expected: "Error: authentication failed, your credentials are invalid or have expired: not allowed\n" +
+				"Hint: run `nhost login`\n",
+		},
+		{
+			name:  "graphql access denied",
+			err:   gqlErrors("access-denied"),
+			debug: false,
+			kind:  &clienv.KindPermissionDenied,
+			code:  clienv.ExitCodePermissionDenied,
+			expected: "Error: permission denied: not allowed\n" +
+				"Hint: make sure your account has access to the project\n",
+		},
+		{
+			name:     "graphql unknown error",
+			err:      gqlErrors("validation-failed"),
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: not allowed\n",
+		},
+		{
+			name:  "docker not installed",
+			err:   fmt.Errorf("failed to start: %w", &exec.Error{Name: "docker", Err: exec.ErrNotFound}),
+			debug: false,
+			kind:  &clienv.KindDockerUnavailable,
+			code:  clienv.ExitCodeDockerUnavailable,
+			expected: "Error: docker is not available: executable file not found in $PATH\n" +
+				"Hint: make sure docker is installed and running\n",
+		},
+		{
+			name: "docker daemon not running",
+			err: fmt.Errorf("failed to start docker compose: %w", &dockercompose.CommandError{
+				Err:    errors.New("exit status 1"), //nolint:goerr113
+				Stderr: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock.",
+			}),
+			debug: true,
+			kind:  &clienv.KindDockerUnavailable,
+			code:  clienv.ExitCodeDockerUnavailable,
+			expected: "Error: docker is not available: exit status 1\n" +
+				"Hint: make sure docker is installed and running\n" +
+				"Details:\n" +
+				"failed to start docker compose: exit status 1\n" +
+				"Command output:\n" +
+				"Cannot connect to the Docker daemon at unix:///var/run/docker.sock.\n",
+		},
+		{
+			name: "file permission denied",
+			err: fmt.Errorf("failed to write file: %w", &os.PathError{
+				Op: "open", Path: "nhost/nhost.toml", Err: os.ErrPermission,
+			}),
+			debug: false,
+			kind:  &clienv.KindFilePermissionDenied,
+			code:  clienv.ExitCodePermissionDenied,
+			expected: "Error: permission denied: permission denied\n" +
+				"Hint: check the permissions of the file or run from a folder you own\n",
+		},
+		{
+			name: "network",
+			err: fmt.Errorf("failed to get apps: %w", &nhostclient.NetworkError{
+				Err: &net.OpError{
+					Op: "dial", Net: "tcp", Source: nil, Addr: nil, Err: errors.New("connection refused"), //nolint:goerr113
+				},
+			}),
+			debug: false,
+			kind:  &clienv.KindNetwork,
+			code:  clienv.ExitCodeNetwork,
+			expected: "Error: failed to reach the Nhost API: connection refused\n" +
+				"Hint: check your internet connection and try again\n",
+		},
+		{
+			name: "api timeout",
+			err: fmt.Errorf("failed to get apps: %w", &nhostclient.NetworkError{
+				Err: context.DeadlineExceeded,
+			}),
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: failed to get apps: context deadline exceeded\n",
+		},
+		{
+			name: "local network error",
+			err: fmt.Errorf("failed to reach postgres: %w", &net.OpError{
+				Op: "dial", Net: "tcp", Source: nil, Addr: nil, Err: errors.New("connection refused"), //nolint:goerr113
+			}),
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: failed to reach postgres: dial tcp: connection refused\n",
+		},
+		{
+			name: "file error",
+			err: fmt.Errorf("failed to read settings: %w", &os.PathError{
+				Op: "open", Path: "nhost/settings.toml", Err: syscall.ENOTDIR,
+			}),
+			debug:    false,
+			kind:     nil,
+			code:     clienv.ExitCodeGeneric,
+			expected: "Error: failed to read settings: open nhost/settings.toml: not a directory\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			classified, ok := clienv.Classify(tc.err)
+			switch {
+			case tc.kind == nil && ok:
+				t.Errorf("unexpected kind %v", classified.Kind)
+			case tc.kind != nil && !ok:
+				t.Errorf("expected kind %v, error wasn't classified", *tc.kind)
+			case tc.kind != nil:
+				if diff := cmp.Diff(*tc.kind, classified.Kind); diff != "" {
+					t.Error(diff)
+				}
+			}
+
+			var buf bytes.Buffer
+			code := clienv.PrintError(&buf, tc.err, tc.debug)
+			if code != tc.code {
+				t.Errorf("expected exit code %d, got %d", tc.code, code)
+			}
+			if diff := cmp.Diff(tc.expected, buf.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDebugRequested(t *testing.T) { //nolint:paralleltest
+	cases := []struct {
+		name     string
+		args     []string
+		env      string
+		expected bool
+	}{
+		{
+			name:     "not requested",
+			args:     []string{"nhost", "up"},
+			env:      "",
+			expected: false,
+		},
+		{
+			name:     "flag",
+			args:     []string{"nhost", "--debug", "up"},
+			env:      "",
+			expected: true,
+		},
+		{
+			name:     "flag with value",
+			args:     []string{"nhost", "up", "-debug=false"},
+			env:      "true",
+			expected: false,
+		},
+		{
+			name:     "after --",
+			args:     []string{"nhost", "run", "--", "node", "--debug"},
+			env:      "",
+			expected: false,
+		},
+		{
+			name:     "env",
+			args:     []string{"nhost", "up"},
+			env:      "1",
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("NHOST_DEBUG", tc.env)
+			if got := clienv.DebugRequested(tc.args); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
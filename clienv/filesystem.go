@@ -11,6 +11,7 @@ type PathStructure struct {
 	dotNhostFolder string
 	dataFolder     string
 	nhostFolder    string
+	authFile       string
 }
 
 func NewPathStructure(
@@ -22,6 +23,7 @@ func NewPathStructure(
 		dotNhostFolder: dotNhostFolder,
 		dataFolder:     dataFolder,
 		nhostFolder:    nhostFolder,
+		authFile:       ContextAuthFile(DefaultContext),
 	}
 }
 
@@ -46,7 +48,7 @@ func (p PathStructure) NhostFolder() string {
 }
 
 func (p PathStructure) AuthFile() string {
-	return filepath.Join(PathStateHome(), "auth.json")
+	return p.authFile
 }
 
 func (p PathStructure) NhostToml() string {
@@ -69,6 +71,14 @@ func (p PathStructure) HasuraConfig() string {
 	return filepath.Join(p.nhostFolder, "config.yaml")
 }
 
+func (p PathStructure) StorageSeedsFolder() string {
+	return filepath.Join(p.nhostFolder, "storage-seeds")
+}
+
+func (p PathStructure) AuthSeeds() string {
+	return filepath.Join(p.nhostFolder, "auth-seeds.yaml")
+}
+
 func (p PathStructure) ProjectFile() string {
 	return filepath.Join(p.dotNhostFolder, "project.json")
 }
@@ -92,3 +102,12 @@ func PathStateHome() string {
 
 	return path
 }
+
+// PathPluginsHome returns the folder where `nhost plugin install` installs
+// plugins.
+func PathPluginsHome() string {
+	if os.Getenv("XDG_DATA_HOME") != "" {
+		return filepath.Join(os.Getenv("XDG_DATA_HOME"), "nhost", "plugins")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".nhost", "plugins")
+}
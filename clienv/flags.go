@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/urfave/cli/v2"
@@ -16,6 +17,11 @@ const (
 	flagDataFolder     = "data-folder"
 	flagNhostFolder    = "nhost-folder"
 	flagDotNhostFolder = "dot-nhost-folder"
+	flagYes            = "yes"
+	flagNonInteractive = "non-interactive"
+	flagOutput         = "output"
+	flagDebug          = "debug"
+	flagContext        = "context"
 )
 
 func getGitBranchName() string {
@@ -32,6 +38,15 @@ func getGitBranchName() string {
 	return head.Name().Short()
 }
 
+func validateContext(_ *cli.Context, name string) error {
+	contexts, err := LoadContexts()
+	if err != nil {
+		return err
+	}
+	_, err = contexts.Get(name)
+	return err
+}
+
 func Flags() ([]cli.Flag, error) {
 	fullWorkingDir, err := os.Getwd()
 	if err != nil {
@@ -51,6 +66,12 @@ func Flags() ([]cli.Flag, error) {
 			Value:   "nhost.run",
 			Hidden:  true,
 		},
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:    flagContext,
+			Usage:   "Context to use instead of the current one, see `nhost context`",
+			EnvVars: []string{"NHOST_CONTEXT"},
+			Action:  validateContext,
+		},
 		&cli.StringFlag{ //nolint:exhaustruct
 			Name:     flagRootFolder,
 			Usage:    "Root folder of project\n\t",
@@ -85,5 +106,56 @@ func Flags() ([]cli.Flag, error) {
 			Value:   filepath.Base(fullWorkingDir),
 			EnvVars: []string{"NHOST_PROJECT_NAME"},
 		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:    flagYes,
+			Aliases: []string{"y"},
+			Usage:   "Answer yes to all confirmations",
+			EnvVars: []string{"NHOST_YES"},
+		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:    flagNonInteractive,
+			Usage:   "Never ask for input, fail instead naming the flag to use. Enabled automatically when stdin is not a terminal", //nolint:lll
+			EnvVars: []string{"NHOST_NON_INTERACTIVE"},
+		},
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:    flagOutput,
+			Aliases: []string{"o"},
+			Usage:   "Output format: table, json or yaml",
+			Value:   OutputTable,
+			EnvVars: []string{"NHOST_OUTPUT"},
+			Action:  validateOutput,
+		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:    flagDebug,
+			Usage:   "Print the full details of errors",
+			EnvVars: []string{"NHOST_DEBUG"},
+		},
 	}, nil
 }
+
+// flagArg returns the value of the first flag in args named like one of names,
+// empty if it was passed without "=value". Arguments after "--" are ignored.
+func flagArg(args, names []string) (string, bool) {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		for _, n := range names {
+			if name == n {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FlagPassed returns true if f is in args. Unlike cli.Context.IsSet it is
+// false for flags set with an environment variable.
+func FlagPassed(args []string, f cli.Flag) bool {
+	_, ok := flagArg(args, f.Names())
+	return ok
+}
@@ -0,0 +1,93 @@
+package clienv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+func validateOutput(_ *cli.Context, output string) error {
+	switch output {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf( //nolint:goerr113
+			"invalid output %q, supported values: %s, %s, %s",
+			output, OutputTable, OutputJSON, OutputYAML,
+		)
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// disableColorsIfNotTerminal makes sure no escape sequences end up in files or
+// pipes.
+func disableColorsIfNotTerminal(w io.Writer) {
+	if !isTerminal(w) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// Output returns the output format requested with --output.
+func (ce *CliEnv) Output() string {
+	return ce.output
+}
+
+// LogWriter returns where messages that aren't the result of a command are
+// written to. With machine-readable output they go to stderr so stdout can be
+// parsed.
+func (ce *CliEnv) LogWriter() io.Writer {
+	if ce.Output() != OutputTable {
+		return ce.stderr
+	}
+	return ce.stdout
+}
+
+// PrintData writes v to stdout as json or yaml if requested with --output,
+// otherwise it calls table to print it in a human friendly format. Field
+// names are taken from the json tags of v in both json and yaml.
+func (ce *CliEnv) PrintData(v any, table func()) error {
+	switch ce.Output() {
+	case OutputJSON:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		ce.Println("%s", b)
+	case OutputYAML:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		var generic any
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return fmt.Errorf("failed to unmarshal output: %w", err)
+		}
+		b, err = yaml.Marshal(generic)
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		if _, err := ce.stdout.Write(b); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	default:
+		table()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package clienv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// MissingInputError is returned when a question can't be asked because the CLI
+// is running in non-interactive mode.
+type MissingInputError struct {
+	Question string
+	Flags    []string
+}
+
+func (e *MissingInputError) Error() string {
+	return fmt.Sprintf(
+		"%q requires input but the CLI is running in non-interactive mode, use %s",
+		strings.TrimSpace(e.Question), strings.Join(e.Flags, " or "),
+	)
+}
+
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Interactive returns true if the CLI can ask questions to the user.
+func (ce *CliEnv) Interactive() bool {
+	return !ce.nonInteractive
+}
+
+// Confirm asks a yes/no question defaulting to no. If --yes was passed it
+// returns true without asking. In non-interactive mode it fails with a
+// *MissingInputError naming flag, the flag answering the question, if any.
+func (ce *CliEnv) Confirm(flag string, msg string, a ...any) (bool, error) {
+	if ce.yes {
+		return true, nil
+	}
+
+	question := fmt.Sprintf(msg, a...)
+	if ce.nonInteractive {
+		flags := []string{"--" + flagYes}
+		if flag != "" {
+			flags = append([]string{"--" + flag}, flags...)
+		}
+		return false, &MissingInputError{Question: question, Flags: flags}
+	}
+
+	ce.PromptMessage("%s [y/N] ", question)
+	resp, err := ce.PromptInput(false)
+	if err != nil {
+		return false, err
+	}
+	return resp == "y" || resp == "Y", nil
+}
+
+// Input asks for a value. In non-interactive mode it fails with a
+// *MissingInputError naming flag, the flag providing the value.
+func (ce *CliEnv) Input(flag string, hide bool, msg string, a ...any) (string, error) {
+	question := fmt.Sprintf(msg, a...)
+	if ce.nonInteractive {
+		return "", &MissingInputError{Question: question, Flags: []string{"--" + flag}}
+	}
+
+	ce.PromptMessage("%s", question)
+	return ce.PromptInput(hide)
+}
@@ -0,0 +1,193 @@
+package clienv_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+// withCliEnv runs the CLI with args, stdin isn't a terminal so it runs in
+// non-interactive mode, and calls f with its environment.
+func withCliEnv(t *testing.T, args []string, f func(ce *clienv.CliEnv)) {
+	t.Helper()
+
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	app := &cli.App{ //nolint:exhaustruct
+		Name:      "nhost",
+		Flags:     flags,
+		Writer:    &stdout,
+		ErrWriter: &stdout,
+		Action: func(cCtx *cli.Context) error {
+			f(clienv.FromCLI(cCtx))
+			return nil
+		},
+	}
+	if err := app.Run(append([]string{"nhost"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func missingInput(err error) *clienv.MissingInputError {
+	var missing *clienv.MissingInputError
+	if !errors.As(err, &missing) {
+		return nil
+	}
+	return missing
+}
+
+func TestConfirm(t *testing.T) { //nolint:paralleltest
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	cases := []struct {
+		name     string
+		args     []string
+		flag     string
+		expected bool
+		missing  *clienv.MissingInputError
+		errMsg   string
+	}{
+		{
+			name:     "yes",
+			args:     []string{"--yes"},
+			flag:     "down-on-failure",
+			expected: true,
+			missing:  nil,
+			errMsg:   "",
+		},
+		{
+			name:     "non-interactive with flag",
+			args:     []string{"--non-interactive"},
+			flag:     "down-on-failure",
+			expected: false,
+			missing: &clienv.MissingInputError{
+				Question: "Do you want to stop the environment?",
+				Flags:    []string{"--down-on-failure", "--yes"},
+			},
+			errMsg: `"Do you want to stop the environment?" requires input but the CLI is running in non-interactive mode, use --down-on-failure or --yes`, //nolint:lll
+		},
+		{
+			name:     "non-interactive without flag",
+			args:     []string{"--non-interactive"},
+			flag:     "",
+			expected: false,
+			missing: &clienv.MissingInputError{
+				Question: "Do you want to stop the environment?",
+				Flags:    []string{"--yes"},
+			},
+			errMsg: `"Do you want to stop the environment?" requires input but the CLI is running in non-interactive mode, use --yes`, //nolint:lll
+		},
+	}
+
+	for _, tc := range cases { //nolint:paralleltest
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			withCliEnv(t, tc.args, func(ce *clienv.CliEnv) {
+				got, err := ce.Confirm(tc.flag, "Do you want to stop the %s?", "environment")
+				if got != tc.expected {
+					t.Errorf("expected %t, got %t", tc.expected, got)
+				}
+				if diff := cmp.Diff(tc.missing, missingInput(err)); diff != "" {
+					t.Errorf(diff)
+				}
+				if err != nil && err.Error() != tc.errMsg {
+					t.Errorf("expected error %q, got %q", tc.errMsg, err.Error())
+				}
+			})
+		})
+	}
+}
+
+func TestInput(t *testing.T) { //nolint:paralleltest
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	cases := []struct {
+		name    string
+		args    []string
+		missing *clienv.MissingInputError
+	}{
+		{
+			name: "non-interactive",
+			args: []string{"--non-interactive"},
+			missing: &clienv.MissingInputError{
+				Question: "email: ",
+				Flags:    []string{"--email"},
+			},
+		},
+		{
+			name: "yes doesn't answer inputs",
+			args: []string{"--yes", "--non-interactive"},
+			missing: &clienv.MissingInputError{
+				Question: "email: ",
+				Flags:    []string{"--email"},
+			},
+		},
+	}
+
+	for _, tc := range cases { //nolint:paralleltest
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			withCliEnv(t, tc.args, func(ce *clienv.CliEnv) {
+				got, err := ce.Input("email", false, "email: ")
+				if got != "" {
+					t.Errorf("expected no value, got %q", got)
+				}
+				if diff := cmp.Diff(tc.missing, missingInput(err)); diff != "" {
+					t.Errorf(diff)
+				}
+			})
+		})
+	}
+}
+
+func TestLink(t *testing.T) { //nolint:paralleltest
+	// no credentials are stored so linking to a subdomain needs to log in
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	cases := []struct {
+		name      string
+		subdomain string
+		missing   *clienv.MissingInputError
+	}{
+		{
+			name:      "select workspace",
+			subdomain: "",
+			missing: &clienv.MissingInputError{
+				Question: "Select the workspace # to link",
+				Flags:    []string{"--subdomain"},
+			},
+		},
+		{
+			name:      "subdomain",
+			subdomain: "myapp",
+			missing: &clienv.MissingInputError{
+				Question: "email: ",
+				Flags:    []string{"--email"},
+			},
+		},
+	}
+
+	for _, tc := range cases { //nolint:paralleltest
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			withCliEnv(t, []string{"--non-interactive"}, func(ce *clienv.CliEnv) {
+				app, err := ce.Link(context.Background(), tc.subdomain)
+				if app != nil {
+					t.Errorf("expected no app, got %v", app)
+				}
+				if diff := cmp.Diff(tc.missing, missingInput(err)); diff != "" {
+					t.Errorf(diff)
+				}
+			})
+		})
+	}
+}
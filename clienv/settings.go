@@ -0,0 +1,200 @@
+package clienv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	SettingsFile = "settings.toml"
+
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceUser    = "user"
+	SourceProject = "project"
+	SourceDefault = "default"
+
+	settingsMetadataKey = "settings"
+)
+
+// Settings are the defaults for flags read from a settings file. Global flags
+// are top-level keys and the flags of a command are in a table named after
+// the command, for instance:
+//
+//	project-name = "myproject"
+//
+//	[up]
+//	http-port = 8443
+//	disable-tls = true
+type Settings map[string]any
+
+// ProjectSettingsFile returns the settings file committed with the project.
+func ProjectSettingsFile(nhostFolder string) string {
+	return filepath.Join(nhostFolder, SettingsFile)
+}
+
+// UserSettingsFile returns the settings file of the user, it overrides the
+// settings of the project.
+func UserSettingsFile() string {
+	return filepath.Join(PathStateHome(), SettingsFile)
+}
+
+// LoadSettings reads a settings file, if it doesn't exist it returns empty
+// settings.
+func LoadSettings(file string) (Settings, error) {
+	settings := Settings{}
+	if err := UnmarshalFile(file, &settings, toml.Unmarshal); err != nil &&
+		!errors.Is(err, os.ErrNotExist) && !errors.Is(err, ErrNoContent) {
+		return nil, fmt.Errorf("failed to read settings from %s: %w", file, err)
+	}
+	return settings, nil
+}
+
+// Lookup returns the value of the flag name of the command section, the empty
+// section being the global flags.
+func (s Settings) Lookup(section, name string) (any, bool) {
+	if section == "" {
+		v, ok := s[name]
+		if _, isTable := v.(map[string]any); isTable {
+			return nil, false
+		}
+		return v, ok
+	}
+
+	table, ok := s[section].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	v, ok := table[name]
+	return v, ok
+}
+
+// Set sets the value of the flag name of the command section.
+func (s Settings) Set(section, name string, value any) {
+	if section == "" {
+		s[name] = value
+		return
+	}
+
+	table, ok := s[section].(map[string]any)
+	if !ok {
+		table = map[string]any{}
+		s[section] = table
+	}
+	table[name] = value
+}
+
+// Save writes the settings to file.
+func (s Settings) Save(file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create folder for %s: %w", file, err)
+	}
+	if err := MarshalFile(s, file, toml.Marshal); err != nil {
+		return fmt.Errorf("failed to write settings to %s: %w", file, err)
+	}
+	return nil
+}
+
+// FormatSetting returns a value read from a settings file as it would be
+// passed to a flag.
+func FormatSetting(v any) []string {
+	switch v := v.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, FormatSetting(e)...)
+		}
+		return values
+	case string:
+		return []string{v}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// SettingKey returns the key used by `nhost settings` for the flag name of the
+// command section, for instance project-name or up.http-port.
+func SettingKey(section, name string) string {
+	if section == "" {
+		return name
+	}
+	return section + "." + name
+}
+
+// SettingSources returns where the values of the flags set from settings
+// files were read from, indexed by SettingKey.
+func SettingSources(cCtx *cli.Context) map[string]string {
+	sources, _ := cCtx.App.Metadata[settingsMetadataKey].(map[string]string)
+	return sources
+}
+
+// ApplySettings returns a cli.BeforeFunc that sets the flags of the command
+// section, or the global flags if empty, that weren't passed or set with an
+// environment variable to the values in the settings files. The user's
+// settings take precedence over the project's. For the global flags it also
+// checks the selected context exists.
+func ApplySettings(section string) cli.BeforeFunc {
+	return func(cCtx *cli.Context) error {
+		project, err := LoadSettings(ProjectSettingsFile(cCtx.String(flagNhostFolder)))
+		if err != nil {
+			return err
+		}
+		user, err := LoadSettings(UserSettingsFile())
+		if err != nil {
+			return err
+		}
+
+		flags := cCtx.App.Flags
+		if section != "" {
+			flags = cCtx.Command.Flags
+		}
+
+		if cCtx.App.Metadata == nil {
+			cCtx.App.Metadata = map[string]any{}
+		}
+		sources := SettingSources(cCtx)
+		if sources == nil {
+			sources = map[string]string{}
+			cCtx.App.Metadata[settingsMetadataKey] = sources
+		}
+
+		for _, f := range flags {
+			name := f.Names()[0]
+			if cCtx.IsSet(name) {
+				continue
+			}
+
+			value, ok := user.Lookup(section, name)
+			source := SourceUser
+			if !ok {
+				value, ok = project.Lookup(section, name)
+				source = SourceProject
+			}
+			if !ok {
+				continue
+			}
+
+			for _, s := range FormatSetting(value) {
+				if err := cCtx.Set(name, s); err != nil {
+					return fmt.Errorf("invalid %s setting %s: %w", source, name, err)
+				}
+			}
+			sources[SettingKey(section, name)] = source
+		}
+
+		if section == "" {
+			if _, _, err := resolveContext(cCtx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -48,19 +48,19 @@ func (ce *CliEnv) Println(msg string, a ...any) {
 }
 
 func (ce *CliEnv) Infoln(msg string, a ...any) {
-	if _, err := fmt.Fprintln(ce.stdout, info(fmt.Sprintf(msg, a...))); err != nil {
+	if _, err := fmt.Fprintln(ce.LogWriter(), info(fmt.Sprintf(msg, a...))); err != nil {
 		panic(err)
 	}
 }
 
 func (ce *CliEnv) Warnln(msg string, a ...any) {
-	if _, err := fmt.Fprintln(ce.stdout, warn(fmt.Sprintf(msg, a...))); err != nil {
+	if _, err := fmt.Fprintln(ce.LogWriter(), warn(fmt.Sprintf(msg, a...))); err != nil {
 		panic(err)
 	}
 }
 
 func (ce *CliEnv) PromptMessage(msg string, a ...any) {
-	if _, err := fmt.Fprint(ce.stdout, promptMessage("- "+fmt.Sprintf(msg, a...))); err != nil {
+	if _, err := fmt.Fprint(ce.LogWriter(), promptMessage("- "+fmt.Sprintf(msg, a...))); err != nil {
 		panic(err)
 	}
 }
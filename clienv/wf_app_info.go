@@ -51,14 +51,18 @@ func (ce *CliEnv) GetAppInfo(
 	var project *graphql.GetWorkspacesApps_Workspaces_Apps
 	if err := UnmarshalFile(ce.Path.ProjectFile(), &project, json.Unmarshal); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			project, err = ce.Link(ctx)
-			if err != nil {
+			project, err = ce.Link(ctx, "")
+			var missingInputErr *MissingInputError
+			switch {
+			case errors.As(err, &missingInputErr):
+				return nil, NewError(KindNotLinked, err)
+			case err != nil:
 				return nil, err
 			}
 		} else {
-			ce.Warnln("Failed to find linked project: %v", err)
-			ce.Infoln("Please run `nhost link` to link a project first")
-			return nil, err
+			return nil, NewError(
+				KindNotLinked, fmt.Errorf("failed to read linked project: %w", err),
+			)
 		}
 	}
 
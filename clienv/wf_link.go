@@ -9,50 +9,94 @@ import (
 	"github.com/nhost/cli/nhostclient/graphql"
 )
 
-func printlist(ce *CliEnv, workspaces []*graphql.GetWorkspacesApps_Workspaces) error {
-	if len(workspaces) == 0 {
-		return fmt.Errorf("no workspaces found") //nolint:goerr113
-	}
-
-	num := Column{
-		Header: "#",
-		Rows:   make([]string, 0),
-	}
-	subdomain := Column{
-		Header: "Subdomain",
-		Rows:   make([]string, 0),
-	}
-	project := Column{
-		Header: "Project",
-		Rows:   make([]string, 0),
-	}
-	workspace := Column{
-		Header: "Workspace",
-		Rows:   make([]string, 0),
-	}
-	region := Column{
-		Header: "Region",
-		Rows:   make([]string, 0),
-	}
+const flagSubdomain = "subdomain"
+
+// App is a cloud project as shown by `project list` and `link`.
+type App struct {
+	Subdomain string `json:"subdomain"`
+	Name      string `json:"name"`
+	Workspace string `json:"workspace"`
+	Region    string `json:"region"`
+}
 
+// Apps flattens the apps of workspaces in the order they are numbered when
+// selecting one.
+func Apps(workspaces []*graphql.GetWorkspacesApps_Workspaces) []App {
+	apps := make([]App, 0)
 	for _, ws := range workspaces {
 		for _, app := range ws.Apps {
+			apps = append(apps, App{
+				Subdomain: app.Subdomain,
+				Name:      app.Name,
+				Workspace: ws.Name,
+				Region:    app.Region.AwsName,
+			})
+		}
+	}
+	return apps
+}
+
+// PrintApps prints apps as a numbered table or, if requested with --output, as
+// json or yaml.
+func (ce *CliEnv) PrintApps(apps []App) error {
+	return ce.PrintData(apps, func() {
+		num := Column{
+			Header: "#",
+			Rows:   make([]string, 0),
+		}
+		subdomain := Column{
+			Header: "Subdomain",
+			Rows:   make([]string, 0),
+		}
+		project := Column{
+			Header: "Project",
+			Rows:   make([]string, 0),
+		}
+		workspace := Column{
+			Header: "Workspace",
+			Rows:   make([]string, 0),
+		}
+		region := Column{
+			Header: "Region",
+			Rows:   make([]string, 0),
+		}
+
+		for _, app := range apps {
 			num.Rows = append(num.Rows, fmt.Sprintf("%d", len(num.Rows)+1))
 			subdomain.Rows = append(subdomain.Rows, app.Subdomain)
 			project.Rows = append(project.Rows, app.Name)
-			workspace.Rows = append(workspace.Rows, ws.Name)
-			region.Rows = append(region.Rows, app.Region.AwsName)
+			workspace.Rows = append(workspace.Rows, app.Workspace)
+			region.Rows = append(region.Rows, app.Region)
 		}
-	}
 
-	ce.Println(Table(num, subdomain, project, workspace, region))
+		ce.Println(Table(num, subdomain, project, workspace, region))
+	})
+}
+
+// filterWorkspaces returns only the workspace named workspace, if set.
+func filterWorkspaces(
+	workspaces []*graphql.GetWorkspacesApps_Workspaces,
+	workspace string,
+) []*graphql.GetWorkspacesApps_Workspaces {
+	if workspace == "" {
+		return workspaces
+	}
 
-	return nil
+	filtered := make([]*graphql.GetWorkspacesApps_Workspaces, 0, 1)
+	for _, ws := range workspaces {
+		if ws.Name == workspace {
+			filtered = append(filtered, ws)
+		}
+	}
+	return filtered
 }
 
 func confirmApp(ce *CliEnv, app *graphql.GetWorkspacesApps_Workspaces_Apps) error {
-	ce.PromptMessage("Enter project subdomain to confirm: ")
-	confirm, err := ce.PromptInput(false)
+	if ce.yes {
+		return nil
+	}
+
+	confirm, err := ce.Input(flagSubdomain, false, "Enter project subdomain to confirm: ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
@@ -89,7 +133,27 @@ OUTER:
 	return app, nil
 }
 
-func (ce *CliEnv) Link(ctx context.Context) (*graphql.GetWorkspacesApps_Workspaces_Apps, error) {
+// Link links the local project to the cloud project with the given subdomain
+// or, if empty, to the one the user selects.
+func (ce *CliEnv) Link(
+	ctx context.Context,
+	subdomain string,
+) (*graphql.GetWorkspacesApps_Workspaces_Apps, error) {
+	if subdomain != "" {
+		app, err := getRemoteAppInfo(ctx, ce, subdomain)
+		if err != nil {
+			return nil, err
+		}
+		return app, saveLink(ce, app)
+	}
+
+	if ce.nonInteractive {
+		return nil, &MissingInputError{
+			Question: "Select the workspace # to link",
+			Flags:    []string{"--" + flagSubdomain},
+		}
+	}
+
 	session, err := ce.LoadSession(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load session: %w", err)
@@ -104,21 +168,21 @@ func (ce *CliEnv) Link(ctx context.Context) (*graphql.GetWorkspacesApps_Workspac
 		return nil, fmt.Errorf("failed to get workspaces: %w", err)
 	}
 
-	if len(workspaces.GetWorkspaces()) == 0 {
+	available := filterWorkspaces(workspaces.GetWorkspaces(), ce.workspace)
+	if len(available) == 0 {
 		return nil, fmt.Errorf("no workspaces found") //nolint:goerr113
 	}
 
-	if err := printlist(ce, workspaces.GetWorkspaces()); err != nil {
+	if err := ce.PrintApps(Apps(available)); err != nil {
 		return nil, err
 	}
 
-	ce.PromptMessage("Select the workspace # to link: ")
-	idx, err := ce.PromptInput(false)
+	idx, err := ce.Input(flagSubdomain, false, "Select the workspace # to link: ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read workspace: %w", err)
 	}
 
-	app, err := getApp(workspaces.GetWorkspaces(), idx)
+	app, err := getApp(available, idx)
 	if err != nil {
 		return nil, err
 	}
@@ -127,13 +191,17 @@ func (ce *CliEnv) Link(ctx context.Context) (*graphql.GetWorkspacesApps_Workspac
 		return nil, err
 	}
 
+	return app, saveLink(ce, app)
+}
+
+func saveLink(ce *CliEnv, app *graphql.GetWorkspacesApps_Workspaces_Apps) error {
 	if err := os.MkdirAll(ce.Path.DotNhostFolder(), 0o755); err != nil { //nolint:gomnd
-		return nil, fmt.Errorf("failed to create .nhost folder: %w", err)
+		return fmt.Errorf("failed to create .nhost folder: %w", err)
 	}
 
 	if err := MarshalFile(app, ce.Path.ProjectFile(), json.Marshal); err != nil {
-		return nil, fmt.Errorf("failed to marshal project information: %w", err)
+		return fmt.Errorf("failed to marshal project information: %w", err)
 	}
 
-	return app, nil
+	return nil
 }
@@ -19,16 +19,14 @@ func (ce *CliEnv) Login(
 
 	var err error
 	if email == "" {
-		ce.PromptMessage("email: ")
-		email, err = ce.PromptInput(false)
+		email, err = ce.Input("email", false, "email: ")
 		if err != nil {
 			return credentials.Credentials{}, fmt.Errorf("failed to read email: %w", err)
 		}
 	}
 
 	if password == "" {
-		ce.PromptMessage("password: ")
-		password, err = ce.PromptInput(true)
+		password, err = ce.Input("password", true, "password: ")
 		ce.Println("")
 		if err != nil {
 			return credentials.Credentials{}, fmt.Errorf("failed to read password: %w", err)
@@ -49,16 +47,24 @@ func (ce *CliEnv) Login(
 	ce.Infoln("Successfully created PAT")
 	ce.Infoln("Storing PAT for future user")
 
+	if err := ce.storeCredentials(session); err != nil {
+		return credentials.Credentials{}, err
+	}
+
+	return session, nil
+}
+
+func (ce *CliEnv) storeCredentials(creds credentials.Credentials) error {
 	dir := filepath.Dir(ce.Path.AuthFile())
 	if !PathExists(dir) {
 		if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
-			return credentials.Credentials{}, fmt.Errorf("failed to create dir: %w", err)
+			return fmt.Errorf("failed to create dir: %w", err)
 		}
 	}
 
-	if err := MarshalFile(session, ce.Path.AuthFile(), json.Marshal); err != nil {
-		return credentials.Credentials{}, fmt.Errorf("failed to write PAT to file: %w", err)
+	if err := MarshalFile(creds, ce.Path.AuthFile(), json.Marshal); err != nil {
+		return fmt.Errorf("failed to write PAT to file: %w", err)
 	}
 
-	return session, nil
+	return nil
 }
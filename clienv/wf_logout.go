@@ -0,0 +1,58 @@
+package clienv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/nhost/cli/nhostclient"
+	"github.com/nhost/cli/nhostclient/credentials"
+)
+
+// Logout revokes the stored PAT and the session created to do so and deletes
+// the credentials of the current context.
+func (ce *CliEnv) Logout(ctx context.Context) error {
+	var creds credentials.Credentials
+	if err := UnmarshalFile(ce.Path.AuthFile(), &creds, json.Unmarshal); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			ce.Infoln("Not logged in")
+			return nil
+		}
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	cl := ce.GetNhostClient()
+	session, err := cl.LoginPAT(ctx, creds.PersonalAccessToken)
+	var apiErr *nhostclient.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+		// the PAT expired or was revoked already, there is nothing to revoke
+		ce.Warnln("Personal access token is no longer valid, removing it")
+	case err != nil:
+		return fmt.Errorf("failed to login: %w", err)
+	default:
+		ce.Infoln("Revoking personal access token")
+		if err := cl.DeletePAT(
+			ctx, creds.PersonalAccessToken, session.Session.AccessToken,
+		); err != nil {
+			return fmt.Errorf("failed to revoke PAT: %w", err)
+		}
+		if err := cl.Logout(
+			ctx, session.Session.RefreshToken, session.Session.AccessToken,
+		); err != nil {
+			return fmt.Errorf("failed to logout: %w", err)
+		}
+	}
+
+	for _, f := range []string{ce.Path.AuthFile(), ce.appsCacheFile()} {
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", f, err)
+		}
+	}
+
+	ce.Infoln("Successfully logged out")
+	return nil
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/nhost/cli/nhostclient/credentials"
 )
 
+// patRotationWindow is how long before it expires the stored PAT is replaced.
+const patRotationWindow = 14 * 24 * time.Hour
+
 func (ce *CliEnv) LoadSession(
 	ctx context.Context,
 ) (credentials.Session, error) {
@@ -25,5 +29,41 @@ func (ce *CliEnv) LoadSession(
 		return credentials.Session{}, fmt.Errorf("failed to login: %w", err)
 	}
 
+	// PATs created before their expiration was stored have a zero ExpiresAt
+	if !creds.ExpiresAt.IsZero() && time.Until(creds.ExpiresAt) < patRotationWindow {
+		ce.Warnln(
+			"Your personal access token expires on %s, rotating it",
+			creds.ExpiresAt.Format(time.DateOnly),
+		)
+		if err := ce.rotatePAT(ctx, creds, session); err != nil {
+			ce.Warnln("Failed to rotate personal access token: %s", err)
+		}
+	}
+
 	return session, nil
 }
+
+// rotatePAT replaces the stored PAT with a new one and revokes the old one.
+func (ce *CliEnv) rotatePAT(
+	ctx context.Context,
+	creds credentials.Credentials,
+	session credentials.Session,
+) error {
+	cl := ce.GetNhostClient()
+	newCreds, err := cl.CreatePAT(ctx, session.Session.AccessToken)
+	if err != nil {
+		return fmt.Errorf("failed to create PAT: %w", err)
+	}
+
+	if err := ce.storeCredentials(newCreds); err != nil {
+		return err
+	}
+
+	if err := cl.DeletePAT(
+		ctx, creds.PersonalAccessToken, session.Session.AccessToken,
+	); err != nil {
+		return fmt.Errorf("failed to revoke previous PAT: %w", err)
+	}
+
+	return nil
+}
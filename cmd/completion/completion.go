@@ -0,0 +1,93 @@
+package completion
+
+import (
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+// The scripts call the CLI with --generate-bash-completion to get suggestions
+// so they include values that depend on the project, like subdomains, secret
+// names or services.
+const (
+	bashScript = `# nhost completion for bash
+_nhost_completion() {
+  local cur opts
+  COMPREPLY=()
+  cur="${COMP_WORDS[COMP_CWORD]}"
+  if [[ "$cur" == "-"* ]]; then
+    opts=$("${COMP_WORDS[@]:0:$COMP_CWORD}" "$cur" --generate-bash-completion 2>/dev/null)
+  else
+    opts=$("${COMP_WORDS[@]:0:$COMP_CWORD}" --generate-bash-completion 2>/dev/null)
+  fi
+  COMPREPLY=($(compgen -W "${opts}" -- "${cur}"))
+  return 0
+}
+complete -o bashdefault -o default -F _nhost_completion nhost
+`
+
+	zshScript = `#compdef nhost
+# nhost completion for zsh
+_nhost() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(SHELL=zsh ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion 2>/dev/null)}")
+  else
+    opts=("${(@f)$(SHELL=zsh ${words[@]:0:#words[@]-1} --generate-bash-completion 2>/dev/null)}")
+  fi
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+compdef _nhost nhost
+`
+
+	fishScript = `# nhost completion for fish
+function __nhost_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    if string match -q -- '-*' $current
+        $tokens $current --generate-bash-completion 2>/dev/null
+    else
+        $tokens --generate-bash-completion 2>/dev/null
+    end
+end
+complete -c nhost -f -a '(__nhost_complete)'
+`
+)
+
+//nolint:gochecknoglobals
+var scripts = map[string]string{
+	"bash": bashScript,
+	"zsh":  zshScript,
+	"fish": fishScript,
+}
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "completion",
+		Aliases: []string{},
+		Usage:   "Shell completion for bash, zsh and fish",
+		Subcommands: []*cli.Command{
+			commandScript("bash"),
+			commandScript("zsh"),
+			commandScript("fish"),
+			CommandInstall(),
+		},
+	}
+}
+
+func commandScript(shell string) *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    shell,
+		Aliases: []string{},
+		Usage:   "Print the completion script for " + shell,
+		Action: func(cCtx *cli.Context) error {
+			clienv.FromCLI(cCtx).Println("%s", scripts[shell])
+			return nil
+		},
+	}
+}
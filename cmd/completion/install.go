@@ -0,0 +1,94 @@
+package completion
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+const flagShell = "shell"
+
+func CommandInstall() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "install",
+		Aliases: []string{},
+		Usage:   "Install shell completion for the current user",
+		Description: `For bash and zsh a line loading the completion is added to ~/.bashrc or
+~/.zshrc, for fish the script is written to ~/.config/fish/completions/nhost.fish.
+Restart your shell afterwards.`,
+		Action: commandInstall,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagShell,
+				Usage: "Shell to install completion for: bash, zsh or fish. Defaults to $SHELL",
+				Value: filepath.Base(os.Getenv("SHELL")),
+			},
+		},
+	}
+}
+
+// appendLine adds line to the file unless it's already there.
+func appendLine(file, line string) (bool, error) {
+	b, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if strings.Contains(string(b), line) {
+		return false, nil
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gomnd
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "\n# nhost completion\n%s\n", line); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return true, nil
+}
+
+func commandInstall(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	shell := cCtx.String(flagShell)
+	switch shell {
+	case "bash", "zsh":
+		rc := filepath.Join(home, "."+shell+"rc")
+		added, err := appendLine(rc, fmt.Sprintf("source <(nhost completion %s)", shell))
+		if err != nil {
+			return err
+		}
+		if !added {
+			ce.Infoln("Completion is already installed in %s", rc)
+			return nil
+		}
+		ce.Infoln("Completion installed in %s, restart your shell to use it", rc)
+	case "fish":
+		dir := filepath.Join(home, ".config", "fish", "completions")
+		if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		file := filepath.Join(dir, "nhost.fish")
+		if err := os.WriteFile(file, []byte(fishScript), 0o644); err != nil { //nolint:gomnd,gosec
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		ce.Infoln("Completion installed in %s, restart your shell to use it", file)
+	default:
+		return fmt.Errorf( //nolint:goerr113
+			"unsupported shell %q, supported shells: bash, zsh, fish", shell,
+		)
+	}
+
+	return nil
+}
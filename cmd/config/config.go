@@ -2,7 +2,10 @@ package config
 
 import "github.com/urfave/cli/v2"
 
-const flagSubdomain = "subdomain"
+const (
+	flagSubdomain = "subdomain"
+	flagForce     = "force"
+)
 
 func Command() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
@@ -16,6 +19,7 @@ func Command() *cli.Command {
 			CommandShow(),
 			CommandValidate(),
 			CommandEdit(),
+			CommandUpgrade(),
 		},
 	}
 }
@@ -23,10 +23,11 @@ const (
 
 func CommandEdit() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:    "edit",
-		Aliases: []string{},
-		Usage:   "Edit base configuration or an overlay",
-		Action:  edit,
+		Name:         "edit",
+		Aliases:      []string{},
+		Usage:        "Edit base configuration or an overlay",
+		Action:       edit,
+		BashComplete: clienv.CompleteSubdomain(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{ //nolint:exhaustruct
 				Name:    flagSubdomain,
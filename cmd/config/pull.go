@@ -24,16 +24,22 @@ const (
 
 func CommandPull() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:    "pull",
-		Aliases: []string{},
-		Usage:   "Get cloud configuration",
-		Action:  commandPull,
+		Name:         "pull",
+		Aliases:      []string{},
+		Usage:        "Get cloud configuration",
+		Action:       commandPull,
+		BashComplete: clienv.CompleteSubdomain(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{ //nolint:exhaustruct
 				Name:    flagSubdomain,
 				Usage:   "Pull this subdomain's configuration. Defaults to linked project",
 				EnvVars: []string{"NHOST_SUBDOMAIN"},
 			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:    flagForce,
+				Usage:   "Overwrite existing configuration and secrets without asking",
+				EnvVars: []string{"NHOST_FORCE"},
+			},
 		},
 	}
 }
@@ -41,12 +47,13 @@ func CommandPull() *cli.Command {
 func commandPull(cCtx *cli.Context) error {
 	ce := clienv.FromCLI(cCtx)
 
-	if err := verifyFile(ce, ce.Path.NhostToml()); err != nil {
+	force := cCtx.Bool(flagForce)
+	if err := verifyFile(ce, ce.Path.NhostToml(), force); err != nil {
 		return err
 	}
 
 	writeSecrets := true
-	if err := verifyFile(ce, ce.Path.Secrets()); err != nil {
+	if err := verifyFile(ce, ce.Path.Secrets(), force); err != nil {
 		writeSecrets = false
 	}
 
@@ -63,18 +70,17 @@ func commandPull(cCtx *cli.Context) error {
 	return err
 }
 
-func verifyFile(ce *clienv.CliEnv, name string) error {
-	if clienv.PathExists(name) {
-		ce.PromptMessage(
-			fmt.Sprintf("%s already exists. Do you want to overwrite it? [y/N] ", name),
-		)
-		resp, err := ce.PromptInput(false)
-		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-		if resp != "y" && resp != "Y" {
-			return fmt.Errorf("aborting") //nolint:goerr113
-		}
+func verifyFile(ce *clienv.CliEnv, name string, force bool) error {
+	if force || !clienv.PathExists(name) {
+		return nil
+	}
+
+	ok, err := ce.Confirm(flagForce, "%s already exists. Do you want to overwrite it?", name)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !ok {
+		return fmt.Errorf("aborting") //nolint:goerr113
 	}
 	return nil
 }
@@ -10,11 +10,12 @@ import (
 
 func CommandShow() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:        "show",
-		Aliases:     []string{},
-		Usage:       "Shows configuration after resolving secrets",
-		Description: "Note that this command will always use the local secrets, even if you specify subdomain",
-		Action:      commandShow,
+		Name:         "show",
+		Aliases:      []string{},
+		Usage:        "Shows configuration after resolving secrets",
+		Description:  "Note that this command will always use the local secrets, even if you specify subdomain",
+		Action:       commandShow,
+		BashComplete: clienv.CompleteSubdomain(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{ //nolint:exhaustruct
 				Name:    flagSubdomain,
@@ -38,6 +39,5 @@ func commandShow(c *cli.Context) error {
 		return fmt.Errorf("error marshalling config: %w", err)
 	}
 
-	ce.Println(string(b))
-	return nil
+	return ce.PrintData(cfg, func() { ce.Println(string(b)) }) //nolint:wrapcheck
 }
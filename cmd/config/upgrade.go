@@ -0,0 +1,380 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsonpatch "github.com/mattbaird/jsonpatch"
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/be/services/mimir/schema"
+	"github.com/nhost/be/services/mimir/schema/appconfig"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/nhost/cli/project"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagService = "service"
+	flagDryRun  = "dry-run"
+)
+
+var (
+	tableRe   = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.-]+)\s*\]`)
+	versionRe = regexp.MustCompile(`^(\s*version\s*=\s*)(['"])([^'"]*)(['"])(.*)$`)
+	partsRe   = regexp.MustCompile(`\d+|[^\d.\-]+`)
+)
+
+func CommandUpgrade() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "upgrade",
+		Aliases: []string{},
+		Usage:   "Upgrade services to the latest versions supported by this version of the CLI",
+		Action:  commandUpgrade,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{ //nolint:exhaustruct
+				Name:  flagService,
+				Usage: "Only upgrade this service (hasura, auth, storage or postgres). Can be specified multiple times", //nolint:lll
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:  flagDryRun,
+				Usage: "Show what would change without modifying any file",
+				Value: false,
+			},
+		},
+	}
+}
+
+type VersionChange struct {
+	Service string `json:"service"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// LatestVersions returns the versions of the services the bundled schema
+// defaults to, indexed by the name of their table in nhost.toml.
+func LatestVersions() (map[string]string, error) {
+	cfg, err := project.DefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default config: %w", err)
+	}
+
+	return map[string]string{
+		"hasura":   *cfg.GetHasura().GetVersion(),
+		"auth":     *cfg.GetAuth().GetVersion(),
+		"storage":  *cfg.GetStorage().GetVersion(),
+		"postgres": *cfg.GetPostgres().GetVersion(),
+	}, nil
+}
+
+// CompareVersions compares versions like v2.25.1-ce or 14.6-20230406-2 by
+// comparing their numeric parts in order. It returns -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	pa := partsRe.FindAllString(strings.TrimPrefix(a, "v"), -1)
+	pb := partsRe.FindAllString(strings.TrimPrefix(b, "v"), -1)
+
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, erra := strconv.Atoi(pa[i])
+		nb, errb := strconv.Atoi(pb[i])
+		switch {
+		case erra == nil && errb == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (erra != nil || errb != nil) && pa[i] != pb[i]:
+			return strings.Compare(pa[i], pb[i])
+		}
+	}
+
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// UpgradeConfig bumps the versions of the services in an nhost.toml file to
+// the ones in latest if they are older. Only the version lines are modified so
+// the formatting and comments of the file are preserved.
+func UpgradeConfig(b []byte, latest map[string]string) ([]byte, []VersionChange) {
+	lines := strings.SplitAfter(string(b), "\n")
+
+	var changes []VersionChange
+	table := ""
+	for i, line := range lines {
+		if m := tableRe.FindStringSubmatch(line); m != nil {
+			table = m[1]
+			continue
+		}
+
+		to, ok := latest[table]
+		if !ok {
+			continue
+		}
+
+		m := versionRe.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if m == nil || CompareVersions(m[3], to) >= 0 {
+			continue
+		}
+
+		lines[i] = m[1] + m[2] + to + m[4] + m[5] + line[len(strings.TrimRight(line, "\r\n")):]
+		changes = append(changes, VersionChange{Service: table, From: m[3], To: to})
+	}
+
+	return []byte(strings.Join(lines, "")), changes
+}
+
+// UpgradeOverlay is like UpgradeConfig but for the json patches of an
+// overlay that pin versions.
+func UpgradeOverlay(b []byte, latest map[string]string) ([]byte, []VersionChange, error) {
+	var patches []jsonpatch.JsonPatchOperation
+	if err := json.Unmarshal(b, &patches); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse json patches: %w", err)
+	}
+
+	var changes []VersionChange
+	for i, p := range patches {
+		service, ok := strings.CutSuffix(strings.TrimPrefix(p.Path, "/"), "/version")
+		if !ok || (p.Operation != "add" && p.Operation != "replace") {
+			continue
+		}
+		to, ok := latest[service]
+		if !ok {
+			continue
+		}
+		from, ok := p.Value.(string)
+		if !ok || CompareVersions(from, to) >= 0 {
+			continue
+		}
+
+		patches[i].Value = to
+		changes = append(changes, VersionChange{Service: service, From: from, To: to})
+	}
+
+	if len(changes) == 0 {
+		return b, nil, nil
+	}
+
+	newb, err := json.MarshalIndent(patches, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal json patches: %w", err)
+	}
+	return newb, changes, nil
+}
+
+// unsupportedSettings returns the settings in the configuration the bundled
+// schema doesn't know about.
+func unsupportedSettings(b []byte) ([]string, error) {
+	cfg := &model.ConfigConfig{} //nolint:exhaustruct
+	decoder := toml.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(cfg)
+	var strictErr *toml.StrictMissingError
+	switch {
+	case errors.As(err, &strictErr):
+		settings := make([]string, 0, len(strictErr.Errors))
+		for _, e := range strictErr.Errors {
+			settings = append(settings, strings.Join(e.Key(), "."))
+		}
+		return settings, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return nil, nil
+}
+
+func validateUpgraded(b []byte, secrets model.Secrets) error {
+	cfg := &model.ConfigConfig{} //nolint:exhaustruct
+	if err := toml.Unmarshal(b, cfg); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	sch, err := schema.New()
+	if err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	if _, err := appconfig.Config(sch, cfg, secrets); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
+
+func filterVersions(latest map[string]string, services []string) (map[string]string, error) {
+	if len(services) == 0 {
+		return latest, nil
+	}
+
+	filtered := make(map[string]string, len(services))
+	for _, s := range services {
+		v, ok := latest[s]
+		if !ok {
+			return nil, fmt.Errorf( //nolint:goerr113
+				"unknown service %s, supported services: auth, hasura, postgres, storage", s,
+			)
+		}
+		filtered[s] = v
+	}
+	return filtered, nil
+}
+
+// UpgradeChange is a version changed in file, nhost.toml or an overlay.
+type UpgradeChange struct {
+	File string `json:"file"`
+	VersionChange
+}
+
+// UpgradeReport is the result of upgrading the configuration.
+type UpgradeReport struct {
+	Changes []UpgradeChange `json:"changes"`
+	// UnsupportedSettings are settings the upgraded schema doesn't know about
+	UnsupportedSettings []string `json:"unsupportedSettings"`
+	// ValidationError is why the upgraded configuration isn't valid, if it isn't
+	ValidationError string `json:"validationError,omitempty"`
+	// PostgresMajorUpgrade is true if the local data needs to be upgraded
+	PostgresMajorUpgrade bool `json:"postgresMajorUpgrade"`
+	DryRun               bool `json:"dryRun"`
+}
+
+func printUpgradeReport(ce *clienv.CliEnv, report UpgradeReport) {
+	ce.Println("Version changes:")
+	for _, c := range report.Changes {
+		ce.Println("- %s: %s %s -> %s", c.File, c.Service, c.From, c.To)
+	}
+	if len(report.Changes) == 0 {
+		ce.Println("- none, all services are up to date")
+		return
+	}
+
+	if len(report.UnsupportedSettings) > 0 {
+		ce.Warnln("The following settings are not supported anymore and should be removed:")
+		for _, s := range report.UnsupportedSettings {
+			ce.Println("- %s", s)
+		}
+	}
+	if report.ValidationError != "" {
+		ce.Warnln("The upgraded configuration needs changes before it can be used:")
+		ce.Println("%s", report.ValidationError)
+	}
+	if report.PostgresMajorUpgrade {
+		ce.Warnln(
+			"postgres was upgraded to a new major version, run `nhost dev db upgrade` to upgrade your local data", //nolint:lll
+		)
+	}
+	if report.DryRun {
+		ce.Infoln("Dry run, no files were modified")
+	}
+}
+
+func commandUpgrade(cCtx *cli.Context) error { //nolint:funlen,cyclop
+	ce := clienv.FromCLI(cCtx)
+
+	latest, err := LatestVersions()
+	if err != nil {
+		return err
+	}
+	latest, err = filterVersions(latest, cCtx.StringSlice(flagService))
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(ce.Path.NhostToml())
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	upgraded, changes := UpgradeConfig(b, latest)
+
+	report := UpgradeReport{
+		Changes:              []UpgradeChange{},
+		UnsupportedSettings:  []string{},
+		ValidationError:      "",
+		PostgresMajorUpgrade: false,
+		DryRun:               cCtx.Bool(flagDryRun),
+	}
+	files := map[string][]byte{}
+	if len(changes) > 0 {
+		files[ce.Path.NhostToml()] = upgraded
+	}
+	for _, c := range changes {
+		report.Changes = append(report.Changes, UpgradeChange{File: "nhost.toml", VersionChange: c})
+	}
+
+	overlays, err := filepath.Glob(filepath.Join(ce.Path.OverlaysFolder(), "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list overlays: %w", err)
+	}
+	sort.Strings(overlays)
+	for _, overlay := range overlays {
+		ob, err := os.ReadFile(overlay)
+		if err != nil {
+			return fmt.Errorf("failed to read overlay: %w", err)
+		}
+		upgradedOverlay, changes, err := UpgradeOverlay(ob, latest)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade %s: %w", overlay, err)
+		}
+		if len(changes) > 0 {
+			files[overlay] = upgradedOverlay
+		}
+		for _, c := range changes {
+			report.Changes = append(report.Changes, UpgradeChange{
+				File:          filepath.Join("overlays", filepath.Base(overlay)),
+				VersionChange: c,
+			})
+		}
+	}
+
+	if len(report.Changes) == 0 {
+		return ce.PrintData(report, func() { printUpgradeReport(ce, report) }) //nolint:wrapcheck
+	}
+
+	settings, err := unsupportedSettings(upgraded)
+	if err != nil {
+		return err
+	}
+	report.UnsupportedSettings = append(report.UnsupportedSettings, settings...)
+
+	if secrets, err := Secrets(ce); err != nil {
+		ce.Warnln("Skipping validation of the upgraded configuration: %s", err)
+	} else if err := validateUpgraded(upgraded, secrets); err != nil {
+		report.ValidationError = err.Error()
+	}
+
+	for _, c := range report.Changes {
+		if c.Service == "postgres" &&
+			dockercompose.PostgresMajorVersion(c.From) != dockercompose.PostgresMajorVersion(c.To) {
+			report.PostgresMajorUpgrade = true
+			break
+		}
+	}
+
+	if err := ce.PrintData(report, func() { printUpgradeReport(ce, report) }); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if report.DryRun {
+		return nil
+	}
+
+	for file, b := range files {
+		if err := os.WriteFile(file, b, 0o644); err != nil { //nolint:gomnd,gosec
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	ce.Infoln("Configuration upgraded")
+	return nil
+}
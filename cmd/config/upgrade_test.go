@@ -0,0 +1,141 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/cmd/config"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "v2.25.0-ce", b: "v2.25.1-ce", expected: -1},
+		{a: "v2.25.1-ce", b: "v2.25.1-ce", expected: 0},
+		{a: "v2.100.0-ce", b: "v2.25.1-ce", expected: 1},
+		{a: "0.3.4", b: "0.3.10", expected: -1},
+		{a: "14.6-20230406-1", b: "14.6-20230406-2", expected: -1},
+		{a: "15.2-20230406-1", b: "14.6-20230406-2", expected: 1},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+
+			if got := config.CompareVersions(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUpgradeConfig(t *testing.T) {
+	t.Parallel()
+
+	latest := map[string]string{
+		"hasura":   "v2.25.1-ce",
+		"auth":     "0.20.1",
+		"postgres": "14.6-20230406-2",
+	}
+
+	input := `[global]
+
+[hasura]
+# pinned for reasons
+version = 'v2.24.1-ce'
+adminSecret = '{{ secrets.HASURA_GRAPHQL_ADMIN_SECRET }}'
+
+[functions]
+[functions.node]
+version = 16
+
+[auth]
+version = "0.21.0"
+
+[postgres]
+version = '14.6-20230406-1'   # keep
+`
+
+	expected := `[global]
+
+[hasura]
+# pinned for reasons
+version = 'v2.25.1-ce'
+adminSecret = '{{ secrets.HASURA_GRAPHQL_ADMIN_SECRET }}'
+
+[functions]
+[functions.node]
+version = 16
+
+[auth]
+version = "0.21.0"
+
+[postgres]
+version = '14.6-20230406-2'   # keep
+`
+
+	got, changes := config.UpgradeConfig([]byte(input), latest)
+	if diff := cmp.Diff(expected, string(got)); diff != "" {
+		t.Errorf("config mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedChanges := []config.VersionChange{
+		{Service: "hasura", From: "v2.24.1-ce", To: "v2.25.1-ce"},
+		{Service: "postgres", From: "14.6-20230406-1", To: "14.6-20230406-2"},
+	}
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("changes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpgradeOverlay(t *testing.T) {
+	t.Parallel()
+
+	input := `[
+  {
+    "op": "replace",
+    "path": "/auth/redirections/clientUrl",
+    "value": "https://staging.app.io"
+  },
+  {
+    "op": "replace",
+    "path": "/hasura/version",
+    "value": "v2.24.1-ce"
+  }
+]`
+
+	expected := `[
+  {
+    "op": "replace",
+    "path": "/auth/redirections/clientUrl",
+    "value": "https://staging.app.io"
+  },
+  {
+    "op": "replace",
+    "path": "/hasura/version",
+    "value": "v2.25.1-ce"
+  }
+]`
+
+	got, changes, err := config.UpgradeOverlay(
+		[]byte(input), map[string]string{"hasura": "v2.25.1-ce"},
+	)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(got)); diff != "" {
+		t.Errorf("overlay mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedChanges := []config.VersionChange{
+		{Service: "hasura", From: "v2.24.1-ce", To: "v2.25.1-ce"},
+	}
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("changes mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/nhost/be/services/mimir/schema"
 	"github.com/nhost/be/services/mimir/schema/appconfig"
 	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/nhostclient/credentials"
 	"github.com/nhost/cli/nhostclient/graphql"
 	"github.com/nhost/cli/project/env"
 	"github.com/pelletier/go-toml/v2"
@@ -20,10 +21,11 @@ import (
 
 func CommandValidate() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:    "validate",
-		Aliases: []string{},
-		Usage:   "Validate configuration",
-		Action:  commandValidate,
+		Name:         "validate",
+		Aliases:      []string{},
+		Usage:        "Validate configuration",
+		Action:       commandValidate,
+		BashComplete: clienv.CompleteSubdomain(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{ //nolint:exhaustruct
 				Name:    flagSubdomain,
@@ -34,23 +36,49 @@ func CommandValidate() *cli.Command {
 	}
 }
 
+type ValidationResult struct {
+	Subdomain string `json:"subdomain"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
+}
+
 func commandValidate(cCtx *cli.Context) error {
 	ce := clienv.FromCLI(cCtx)
 
 	subdomain := cCtx.String(flagSubdomain)
+	var err error
 	if subdomain != "" && subdomain != "local" {
-		return ValidateRemote(
+		err = ValidateRemote(
 			cCtx.Context,
 			ce,
 			cCtx.String(flagSubdomain),
 		)
+	} else {
+		subdomain = "local"
+		ce.Infoln("Verifying configuration...")
+		if _, err = Validate(ce, "local"); err == nil {
+			ce.Infoln("Configuration is valid!")
+		}
 	}
 
-	ce.Infoln("Verifying configuration...")
-	if _, err := Validate(ce, "local"); err != nil {
+	if ce.Output() == clienv.OutputTable {
 		return err
 	}
-	ce.Infoln("Configuration is valid!")
+
+	result := ValidationResult{Subdomain: subdomain, Valid: err == nil, Error: ""}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if err := ce.PrintData(result, nil); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !result.Valid {
+		code := clienv.ExitCodeGeneric
+		if cliErr, ok := clienv.Classify(err); ok {
+			code = cliErr.Kind.Code
+		}
+		return cli.Exit("", code)
+	}
 	return nil
 }
 
@@ -93,12 +121,8 @@ func applyJSONPatches(
 	return &cfg, nil
 }
 
-func Validate(ce *clienv.CliEnv, subdomain string) (*model.ConfigConfig, error) {
-	cfg := &model.ConfigConfig{} //nolint:exhaustruct
-	if err := clienv.UnmarshalFile(ce.Path.NhostToml(), cfg, toml.Unmarshal); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
-	}
-
+// Secrets returns the secrets of the local development environment.
+func Secrets(ce *clienv.CliEnv) (model.Secrets, error) {
 	var secrets model.Secrets
 	if err := clienv.UnmarshalFile(ce.Path.Secrets(), &secrets, env.Unmarshal); err != nil {
 		return nil, fmt.Errorf(
@@ -106,6 +130,31 @@ func Validate(ce *clienv.CliEnv, subdomain string) (*model.ConfigConfig, error)
 			err,
 		)
 	}
+	return secrets, nil
+}
+
+func Validate(ce *clienv.CliEnv, subdomain string) (*model.ConfigConfig, error) {
+	secrets, err := Secrets(ce)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValidateWithSecrets(ce, subdomain, secrets)
+}
+
+// ValidateWithSecrets is like Validate but resolves the configuration with the
+// given secrets instead of the ones in the .secrets file.
+func ValidateWithSecrets(
+	ce *clienv.CliEnv,
+	subdomain string,
+	secrets model.Secrets,
+) (*model.ConfigConfig, error) {
+	cfg := &model.ConfigConfig{} //nolint:exhaustruct
+	if err := clienv.UnmarshalFile(ce.Path.NhostToml(), cfg, toml.Unmarshal); err != nil {
+		return nil, clienv.NewError(
+			clienv.KindConfigInvalid, fmt.Errorf("failed to parse config: %w", err),
+		)
+	}
 
 	if clienv.PathExists(ce.Path.Overlay(subdomain)) {
 		var err error
@@ -122,7 +171,9 @@ func Validate(ce *clienv.CliEnv, subdomain string) (*model.ConfigConfig, error)
 
 	cfg, err = appconfig.Config(schema, cfg, secrets)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate config: %w", err)
+		return nil, clienv.NewError(
+			clienv.KindConfigInvalid, fmt.Errorf("failed to validate config: %w", err),
+		)
 	}
 
 	return cfg, nil
@@ -135,7 +186,9 @@ func ValidateRemote(
 ) error {
 	cfg := &model.ConfigConfig{} //nolint:exhaustruct
 	if err := clienv.UnmarshalFile(ce.Path.NhostToml(), cfg, toml.Unmarshal); err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+		return clienv.NewError(
+			clienv.KindConfigInvalid, fmt.Errorf("failed to parse config: %w", err),
+		)
 	}
 
 	schema, err := schema.New()
@@ -174,10 +227,74 @@ func ValidateRemote(
 
 	_, err = appconfig.Config(schema, cfg, respToSecrets(secrets.GetAppSecrets(), false))
 	if err != nil {
-		return fmt.Errorf("failed to validate config: %w", err)
+		return clienv.NewError(
+			clienv.KindConfigInvalid, fmt.Errorf("failed to validate config: %w", err),
+		)
 	}
 
 	ce.Infoln("Config is valid!")
 
 	return nil
 }
+
+// Remote returns the configuration deployed to a cloud project with its
+// secrets resolved.
+func Remote(
+	ctx context.Context,
+	ce *clienv.CliEnv,
+	proj *graphql.GetWorkspacesApps_Workspaces_Apps,
+	session credentials.Session,
+) (*model.ConfigConfig, error) {
+	cl := ce.GetNhostClient()
+	resp, err := cl.GetConfigRawJSON(
+		ctx,
+		proj.ID,
+		graphql.WithAccessToken(session.Session.AccessToken),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	cfg := &model.ConfigConfig{} //nolint:exhaustruct
+	if err := json.Unmarshal([]byte(resp.ConfigRawJSON), cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	secrets, err := RemoteSecrets(ctx, ce, proj, session)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := schema.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	cfg, err = appconfig.Config(schema, cfg, secrets)
+	if err != nil {
+		return nil, clienv.NewError(
+			clienv.KindConfigInvalid, fmt.Errorf("failed to validate config: %w", err),
+		)
+	}
+
+	return cfg, nil
+}
+
+// RemoteSecrets returns the secrets of a cloud project.
+func RemoteSecrets(
+	ctx context.Context,
+	ce *clienv.CliEnv,
+	proj *graphql.GetWorkspacesApps_Workspaces_Apps,
+	session credentials.Session,
+) (model.Secrets, error) {
+	secrets, err := ce.GetNhostClient().GetSecrets(
+		ctx,
+		proj.ID,
+		graphql.WithAccessToken(session.Session.AccessToken),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+
+	return respToSecrets(secrets.GetAppSecrets(), false), nil
+}
@@ -0,0 +1,74 @@
+package contexts
+
+import (
+	"fmt"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagDomain    = "domain"
+	flagWorkspace = "workspace"
+	flagUse       = "use"
+)
+
+func CommandAdd() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "add",
+		Aliases:   []string{},
+		Usage:     "Add a context or update an existing one",
+		ArgsUsage: "NAME",
+		Action:    commandAdd,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagDomain,
+				Usage: "Nhost domain",
+				Value: "nhost.run",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagWorkspace,
+				Usage: "Workspace to default to when linking projects",
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:  flagUse,
+				Usage: "Set it as the current context",
+				Value: false,
+			},
+		},
+	}
+}
+
+func commandAdd(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a context name is required") //nolint:goerr113
+	}
+	name := cCtx.Args().First()
+	if err := clienv.ValidateContextName(name); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	contexts, err := clienv.LoadContexts()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	contexts.Contexts[name] = clienv.Context{
+		Domain:    cCtx.String(flagDomain),
+		Workspace: cCtx.String(flagWorkspace),
+	}
+	if cCtx.Bool(flagUse) {
+		contexts.Current = name
+	}
+	if err := contexts.Save(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("Context %s saved", name)
+	if !clienv.PathExists(clienv.ContextAuthFile(name)) {
+		ce.Infoln("Run `nhost --context %s login` to log in", name)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package contexts
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "context",
+		Aliases: []string{},
+		Usage:   "Manage contexts to switch between accounts and Nhost domains",
+		Description: `A context is a Nhost domain, the credentials used with it and, optionally,
+a workspace to default to when linking projects. Each context has its own login.
+The "default" context is always available. For instance:
+
+    nhost context add --domain staging.nhost.run --workspace "My Company" staging
+    nhost --context staging login
+    nhost context use staging`,
+		Subcommands: []*cli.Command{
+			CommandAdd(),
+			CommandList(),
+			CommandRemove(),
+			CommandUse(),
+		},
+	}
+}
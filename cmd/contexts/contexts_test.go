@@ -0,0 +1,144 @@
+package contexts_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/contexts"
+	"github.com/urfave/cli/v2"
+)
+
+func run(t *testing.T, args ...string) {
+	t.Helper()
+
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &cli.App{ //nolint:exhaustruct
+		Name:     "nhost",
+		Flags:    flags,
+		Writer:   io.Discard,
+		Commands: []*cli.Command{contexts.Command()},
+	}
+	if err := app.Run(append([]string{"nhost"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContexts(t *testing.T) { //nolint:paralleltest
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	run(t, "context", "add", "--domain", "staging.nhost.run", "--workspace", "ACME", "staging")
+	run(t, "context", "use", "staging")
+
+	got, err := clienv.LoadContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &clienv.Contexts{
+		Current: "staging",
+		Contexts: map[string]clienv.Context{
+			"default": {Domain: "nhost.run", Workspace: ""},
+			"staging": {Domain: "staging.nhost.run", Workspace: "ACME"},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf(diff)
+	}
+
+	if err := os.MkdirAll(clienv.PathStateHome(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(clienv.ContextAuthFile("staging"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, "--yes", "context", "remove", "staging")
+
+	got, err = clienv.LoadContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = &clienv.Contexts{
+		Current: "default",
+		Contexts: map[string]clienv.Context{
+			"default": {Domain: "nhost.run", Workspace: ""},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf(diff)
+	}
+	if clienv.PathExists(clienv.ContextAuthFile("staging")) {
+		t.Errorf("credentials of the removed context weren't deleted")
+	}
+}
+
+func TestInvalidContexts(t *testing.T) { //nolint:paralleltest
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		contexts string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "unknown current context",
+			contexts: `{"current": "gone", "contexts": {}}`,
+			args:     []string{"context", "list"},
+			expected: "current context gone not found, select another one with `nhost --context default context use <name>`", //nolint:lll
+		},
+		{
+			name:     "unknown current context overridden",
+			contexts: `{"current": "gone", "contexts": {}}`,
+			args:     []string{"--context", "default", "context", "use", "default"},
+			expected: "",
+		},
+		{
+			name:     "invalid contexts file",
+			contexts: `{"current": `,
+			args:     []string{"context", "list"},
+			expected: "failed to read contexts: failed to unmarshal object: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range cases { //nolint:paralleltest
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+			if err := os.MkdirAll(clienv.PathStateHome(), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(
+				filepath.Join(clienv.PathStateHome(), "contexts.json"), []byte(tc.contexts), 0o600,
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			app := &cli.App{ //nolint:exhaustruct
+				Name:     "nhost",
+				Flags:    flags,
+				Writer:   io.Discard,
+				Before:   clienv.ApplySettings(""),
+				Commands: []*cli.Command{contexts.Command()},
+			}
+
+			got := ""
+			if err := app.Run(append([]string{"nhost"}, tc.args...)); err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
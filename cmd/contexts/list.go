@@ -0,0 +1,68 @@
+package contexts
+
+import (
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandList() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "list",
+		Aliases: []string{},
+		Usage:   "List contexts",
+		Action:  commandList,
+	}
+}
+
+type Context struct {
+	Name      string `json:"name"`
+	Domain    string `json:"domain"`
+	Workspace string `json:"workspace"`
+	Current   bool   `json:"current"`
+	LoggedIn  bool   `json:"loggedIn"`
+}
+
+func commandList(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	contexts, err := clienv.LoadContexts()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	list := make([]Context, 0, len(contexts.Contexts))
+	for _, name := range contexts.Names() {
+		c := contexts.Contexts[name]
+		list = append(list, Context{
+			Name:      name,
+			Domain:    c.Domain,
+			Workspace: c.Workspace,
+			Current:   name == ce.ContextName(),
+			LoggedIn:  clienv.PathExists(clienv.ContextAuthFile(name)),
+		})
+	}
+
+	return ce.PrintData(list, func() { //nolint:wrapcheck
+		current := clienv.Column{Header: "", Rows: make([]string, 0, len(list))}
+		name := clienv.Column{Header: "Name", Rows: make([]string, 0, len(list))}
+		domain := clienv.Column{Header: "Domain", Rows: make([]string, 0, len(list))}
+		workspace := clienv.Column{Header: "Workspace", Rows: make([]string, 0, len(list))}
+		loggedIn := clienv.Column{Header: "Logged in", Rows: make([]string, 0, len(list))}
+		for _, c := range list {
+			mark := ""
+			if c.Current {
+				mark = "*"
+			}
+			status := "no"
+			if c.LoggedIn {
+				status = "yes"
+			}
+			current.Rows = append(current.Rows, mark)
+			name.Rows = append(name.Rows, c.Name)
+			domain.Rows = append(domain.Rows, c.Domain)
+			workspace.Rows = append(workspace.Rows, c.Workspace)
+			loggedIn.Rows = append(loggedIn.Rows, status)
+		}
+		ce.Println(clienv.Table(current, name, domain, workspace, loggedIn))
+	})
+}
@@ -0,0 +1,63 @@
+package contexts
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandRemove() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "remove",
+		Aliases:   []string{},
+		Usage:     "Remove a context and its credentials",
+		ArgsUsage: "NAME",
+		Action:    commandRemove,
+	}
+}
+
+func commandRemove(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a context name is required") //nolint:goerr113
+	}
+	name := cCtx.Args().First()
+	if name == clienv.DefaultContext {
+		return fmt.Errorf("the default context can't be removed") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	contexts, err := clienv.LoadContexts()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if _, err := contexts.Get(name); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ok, err := ce.Confirm("", "Remove context %s and its credentials?", name)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !ok {
+		return nil
+	}
+
+	delete(contexts.Contexts, name)
+	if contexts.Current == name {
+		contexts.Current = clienv.DefaultContext
+		ce.Infoln("Switched to context %s", clienv.DefaultContext)
+	}
+	if err := contexts.Save(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := os.Remove(clienv.ContextAuthFile(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+
+	ce.Infoln("Context %s removed", name)
+	return nil
+}
@@ -0,0 +1,43 @@
+package contexts
+
+import (
+	"fmt"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandUse() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "use",
+		Aliases:   []string{},
+		Usage:     "Set the current context",
+		ArgsUsage: "NAME",
+		Action:    commandUse,
+	}
+}
+
+func commandUse(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a context name is required") //nolint:goerr113
+	}
+	name := cCtx.Args().First()
+
+	ce := clienv.FromCLI(cCtx)
+
+	contexts, err := clienv.LoadContexts()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if _, err := contexts.Get(name); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	contexts.Current = name
+	if err := contexts.Save(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("Using context %s", name)
+	return nil
+}
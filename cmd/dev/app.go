@@ -0,0 +1,132 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/run"
+	"github.com/nhost/cli/dockercompose"
+)
+
+const appStopTimeout = 10 * time.Second
+
+func appPidFile(ce *clienv.CliEnv) string {
+	return filepath.Join(ce.Path.DotNhostFolder(), "app.pid")
+}
+
+// runApp runs the frontend dev server with the environment of the local
+// development environment until it exits, the user presses Ctrl-C or
+// `nhost down` is executed.
+func runApp(
+	ctx context.Context,
+	ce *clienv.CliEnv,
+	command string,
+	appPort, httpPort uint,
+	useTLS bool,
+	postgresPort uint,
+) error {
+	vars, err := run.LocalEnv(ce, httpPort, useTLS, postgresPort)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	vars["PORT"] = strconv.FormatUint(uint64(appPort), 10)
+
+	cmd := exec.Command("sh", "-c", command) //nolint:gosec
+	cmd.Env = run.Environ(vars)
+	cmd.Stdout = ce.LogWriter()
+	cmd.Stderr = os.Stderr
+	// run in its own process group so we can stop the dev server along
+	// with any process it spawns, as it isn't in the foreground it can't
+	// read from stdin
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} //nolint:exhaustruct
+
+	if err := dockercompose.WriteAppIngress(
+		ce.Path.DotNhostFolder(), appPort, useTLS,
+	); err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer func() {
+		if err := dockercompose.RemoveAppIngress(ce.Path.DotNhostFolder()); err != nil {
+			ce.Warnln("%s", err)
+		}
+	}()
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start app: %w", err)
+	}
+
+	if err := os.WriteFile(
+		appPidFile(ce), []byte(strconv.Itoa(cmd.Process.Pid)), 0o644, //nolint:gomnd,gosec
+	); err != nil {
+		ce.Warnln("failed to write app pid file: %s", err)
+	}
+	defer os.Remove(appPidFile(ce))
+
+	ce.Infoln(
+		"Running `%s`, available at %s",
+		command, dockercompose.URL("app", httpPort, useTLS),
+	)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-signals:
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}()
+
+	err = cmd.Wait()
+	ce.Infoln(
+		"App stopped, Nhost development environment is still running. Run `nhost down` to stop it",
+	)
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to run app: %w", err)
+	}
+	return nil
+}
+
+// stopApp stops the frontend dev server started by `nhost up --app`, if any.
+func stopApp(ce *clienv.CliEnv) {
+	b, err := os.ReadFile(appPidFile(ce))
+	if err != nil {
+		return
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return
+	}
+
+	ce.Infoln("Stopping app...")
+	if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil {
+		return
+	}
+
+	deadline := time.Now().Add(appStopTimeout)
+	for time.Now().Before(deadline) {
+		if err := syscall.Kill(-pid, 0); err != nil {
+			return
+		}
+		time.Sleep(100 * time.Millisecond) //nolint:gomnd
+	}
+	_ = syscall.Kill(-pid, syscall.SIGKILL)
+}
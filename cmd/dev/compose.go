@@ -12,11 +12,24 @@ func CommandCompose() *cli.Command {
 		Aliases:         []string{},
 		Usage:           "docker compose wrapper, sets project name and compose file automatically",
 		Action:          commandCompose,
+		BashComplete:    clienv.Complete(nil, composeArgs),
 		Flags:           []cli.Flag{},
 		SkipFlagParsing: true,
 	}
 }
 
+// composeArgs suggests docker compose commands for the first argument and the
+// services of the local development environment afterwards.
+func composeArgs(cCtx *cli.Context) []string {
+	if cCtx.NArg() == 0 {
+		return []string{
+			"config", "down", "exec", "images", "kill", "logs", "pause", "port", "ps",
+			"pull", "restart", "rm", "start", "stop", "top", "unpause", "up",
+		}
+	}
+	return serviceNames(cCtx)
+}
+
 func commandCompose(cCtx *cli.Context) error {
 	ce := clienv.FromCLI(cCtx)
 	dc := dockercompose.New(ce.Path.WorkingDir(), ce.Path.DockerCompose(), ce.ProjectName())
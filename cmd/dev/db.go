@@ -0,0 +1,239 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagFromVersion = "from-version"
+)
+
+func CommandDB() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "db",
+		Aliases: []string{},
+		Usage:   "Manage the local database",
+		Subcommands: []*cli.Command{
+			{
+				Name:    "upgrade",
+				Aliases: []string{},
+				Usage:   "Upgrade the local data to the major version of postgres in nhost.toml",
+				Action:  commandDBUpgrade,
+				Flags: []cli.Flag{
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:  flagFromVersion,
+						Usage: "Version of postgres the data was created with. Defaults to the last version used by nhost up", //nolint:lll
+					},
+				},
+			},
+			{
+				Name:    "remove-backup",
+				Aliases: []string{},
+				Usage:   "Remove the backup of the data kept by upgrade",
+				Action:  commandDBRemoveBackup,
+			},
+		},
+	}
+}
+
+func backupPaths(dataFolder, major string) (string, string) {
+	backup := filepath.Join(dataFolder, "db", fmt.Sprintf("pgdata-%s-backup", major))
+	return backup, backup + ".sql"
+}
+
+func fromVersion(cCtx *cli.Context, dataFolder, dataVersion string) (string, error) {
+	if v := cCtx.String(flagFromVersion); v != "" {
+		if dockercompose.PostgresMajorVersion(v) != dataVersion {
+			return "", fmt.Errorf( //nolint:goerr113
+				"data was created with postgres %s but --%s is %s", dataVersion, flagFromVersion, v,
+			)
+		}
+		return v, nil
+	}
+
+	v, err := dockercompose.RecordedPostgresVersion(dataFolder)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	if v == "" || dockercompose.PostgresMajorVersion(v) != dataVersion {
+		return "", fmt.Errorf( //nolint:goerr113
+			"couldn't determine the version of postgres %s used with the data, please, specify it with --%s",
+			dataVersion, flagFromVersion,
+		)
+	}
+	return v, nil
+}
+
+func commandDBUpgrade(cCtx *cli.Context) error { //nolint:funlen,cyclop
+	ce := clienv.FromCLI(cCtx)
+
+	cfg, err := config.Validate(ce, "local")
+	if err != nil {
+		return fmt.Errorf("failed to validate config: %w", err)
+	}
+	to := *cfg.GetPostgres().GetVersion()
+
+	dataFolder := ce.Path.DataFolder()
+	dataVersion, err := dockercompose.PostgresDataVersion(dataFolder)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if dataVersion == "" {
+		ce.Infoln("No local data found, nothing to upgrade")
+		return nil
+	}
+	if dataVersion == dockercompose.PostgresMajorVersion(to) {
+		ce.Infoln("Local data is already on postgres %s, nothing to upgrade", dataVersion)
+		return nil
+	}
+
+	from, err := fromVersion(cCtx, dataFolder, dataVersion)
+	if err != nil {
+		return err
+	}
+
+	pgdata := dockercompose.PostgresDataFolder(dataFolder)
+	backup, dump := backupPaths(dataFolder, dataVersion)
+	if clienv.PathExists(backup) {
+		return fmt.Errorf( //nolint:goerr113
+			"a backup already exists in %s, remove it with `nhost dev db remove-backup` first",
+			backup,
+		)
+	}
+
+	dc := dockercompose.New(ce.Path.WorkingDir(), ce.Path.DockerCompose(), ce.ProjectName())
+	if clienv.PathExists(ce.Path.DockerCompose()) {
+		ce.Infoln("Stopping Nhost development environment...")
+		if err := dc.Stop(cCtx.Context); err != nil {
+			return fmt.Errorf("failed to stop Nhost development environment: %w", err)
+		}
+	}
+
+	docker := dockercompose.NewDocker()
+	name := ce.ProjectName() + "-postgres-upgrade"
+
+	ce.Infoln("Dumping data with postgres %s...", from)
+	if err := dumpData(cCtx.Context, docker, name, from, dataFolder, pgdata, dump); err != nil {
+		return err
+	}
+
+	if err := os.Rename(pgdata, backup); err != nil {
+		return fmt.Errorf("failed to backup data: %w", err)
+	}
+
+	ce.Infoln("Restoring data with postgres %s...", to)
+	if err := restoreData(cCtx.Context, docker, name, to, dataFolder, pgdata, dump); err != nil {
+		ce.Warnln("Upgrade failed, restoring previous data")
+		if rollbackErr := rollbackData(docker, to, pgdata, backup); rollbackErr != nil {
+			return fmt.Errorf(
+				"%w, restoring the previous data also failed (%s), it is kept in %s",
+				err, rollbackErr, backup,
+			)
+		}
+		return err
+	}
+
+	if err := dockercompose.RecordPostgresVersion(dataFolder, to); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("Data upgraded to postgres %s", to)
+	ce.Println("The previous data was kept in %s and its dump in %s", backup, dump)
+	ce.Println("Run `nhost up` to verify everything works and then `nhost dev db remove-backup`")
+	return nil
+}
+
+// rollbackData replaces the data created by a failed upgrade with backup. The
+// new data is owned by the container's user so it is removed from a container.
+func rollbackData(docker *dockercompose.Docker, version, pgdata, backup string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if clienv.PathExists(pgdata) {
+		if err := docker.RemoveFolder(ctx, version, pgdata); err != nil {
+			return err //nolint:wrapcheck
+		}
+		if err := os.Remove(pgdata); err != nil {
+			return fmt.Errorf("failed to remove new data: %w", err)
+		}
+	}
+
+	if err := os.Rename(backup, pgdata); err != nil {
+		return fmt.Errorf("failed to move back previous data: %w", err)
+	}
+	return nil
+}
+
+func dumpData(
+	ctx context.Context,
+	docker *dockercompose.Docker,
+	name, version, dataFolder, pgdata, dump string,
+) error {
+	f, err := os.Create(dump)
+	if err != nil {
+		return fmt.Errorf("failed to create dump file: %w", err)
+	}
+	defer f.Close()
+
+	if err := docker.StartPostgres(ctx, name, version, dataFolder, pgdata); err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer stopContainer(docker, name)
+
+	return docker.DumpPostgres(ctx, name, f) //nolint:wrapcheck
+}
+
+func restoreData(
+	ctx context.Context,
+	docker *dockercompose.Docker,
+	name, version, dataFolder, pgdata, dump string,
+) error {
+	f, err := os.Open(dump)
+	if err != nil {
+		return fmt.Errorf("failed to open dump file: %w", err)
+	}
+	defer f.Close()
+
+	if err := docker.StartPostgres(ctx, name, version, dataFolder, pgdata); err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer stopContainer(docker, name)
+
+	return docker.RestorePostgres(ctx, name, f) //nolint:wrapcheck
+}
+
+func stopContainer(docker *dockercompose.Docker, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_ = docker.StopContainer(ctx, name)
+}
+
+func commandDBRemoveBackup(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	backups, err := filepath.Glob(filepath.Join(ce.Path.DataFolder(), "db", "pgdata-*-backup*"))
+	if err != nil {
+		return fmt.Errorf("failed to find backups: %w", err)
+	}
+	if len(backups) == 0 {
+		ce.Infoln("No backups found")
+		return nil
+	}
+
+	for _, backup := range backups {
+		if err := os.RemoveAll(backup); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", backup, err)
+		}
+		ce.Infoln("Removed %s", backup)
+	}
+	return nil
+}
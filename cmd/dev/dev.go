@@ -9,7 +9,10 @@ func Command() *cli.Command {
 		Usage:   "Operate local development environment",
 		Subcommands: []*cli.Command{
 			CommandCompose(),
+			CommandDB(),
 			CommandHasura(),
+			CommandToken(),
+			CommandUsers(),
 		},
 	}
 }
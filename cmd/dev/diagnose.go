@@ -0,0 +1,380 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/dockercompose"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	diagnoseLogLines = 20
+	reportLogLines   = 500
+)
+
+var (
+	portInUseRe = regexp.MustCompile(
+		`:(\d+).*(?:port is already allocated|address already in use)`,
+	)
+	incompatibleDataRe = regexp.MustCompile(
+		`(?s)database files are incompatible with server.*initialized by PostgreSQL version (\d+)`,
+	)
+)
+
+type Finding struct {
+	Service string
+	Problem string
+	Fix     string
+}
+
+type DiagnoseInput struct {
+	// Stderr is the output of docker compose up
+	Stderr string
+	// Services selected to run, all if nil
+	Services []string
+	Statuses []dockercompose.ServiceStatus
+	Logs     map[string]string
+	// HasuraVersion and PostgresVersion are the configured versions, if known
+	HasuraVersion   string
+	PostgresVersion string
+	// PGDataVersion is the content of PG_VERSION in PGDataFolder, if any
+	PGDataVersion string
+	PGDataFolder  string
+	EmailsFolder  bool
+	HTTPPort      uint
+	PostgresPort  uint
+}
+
+func (in DiagnoseInput) selected(service string) bool {
+	if in.Services == nil {
+		return true
+	}
+	for _, s := range in.Services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+func portFlag(port string, httpPort, postgresPort uint) string {
+	switch port {
+	case fmt.Sprint(httpPort):
+		return "--" + flagHTTPPort
+	case fmt.Sprint(postgresPort):
+		return "--" + flagPostgresPort
+	default:
+		return "the corresponding --*-port flag"
+	}
+}
+
+// Diagnose looks for known causes of failure when starting the development
+// environment.
+func Diagnose(in DiagnoseInput) []Finding { //nolint:funlen
+	var findings []Finding
+
+	for _, m := range portInUseRe.FindAllStringSubmatch(in.Stderr, -1) {
+		findings = append(findings, Finding{
+			Service: "",
+			Problem: fmt.Sprintf("port %s is already in use", m[1]),
+			Fix: fmt.Sprintf(
+				"stop the process using it (i.e. `lsof -i :%s`) or choose another port with %s",
+				m[1], portFlag(m[1], in.HTTPPort, in.PostgresPort),
+			),
+		})
+	}
+
+	if in.HasuraVersion != "" &&
+		semver.Compare(in.HasuraVersion, dockercompose.MinimumHasuraVersion) < 0 {
+		findings = append(findings, Finding{
+			Service: "graphql",
+			Problem: fmt.Sprintf(
+				"hasura version %s is older than the minimum supported version %s",
+				in.HasuraVersion, dockercompose.MinimumHasuraVersion,
+			),
+			Fix: fmt.Sprintf(
+				"set hasura.version to %s or newer in nhost/nhost.toml",
+				dockercompose.MinimumHasuraVersion,
+			),
+		})
+	}
+
+	dataVersion := in.PGDataVersion
+	if dataVersion == "" {
+		if m := incompatibleDataRe.FindStringSubmatch(in.Logs["postgres"]); m != nil {
+			dataVersion = m[1]
+		}
+	}
+	if dataVersion != "" && in.PostgresVersion != "" &&
+		dataVersion != dockercompose.PostgresMajorVersion(in.PostgresVersion) {
+		findings = append(findings, Finding{
+			Service: "postgres",
+			Problem: fmt.Sprintf(
+				"the database in %s was created with postgres %s but postgres %s is configured",
+				in.PGDataFolder, dataVersion, in.PostgresVersion,
+			),
+			Fix: fmt.Sprintf(
+				"run `nhost dev db upgrade` to upgrade the data or set postgres.version back to a %s.x version", //nolint:lll
+				dataVersion,
+			),
+		})
+	}
+
+	if !in.EmailsFolder && in.selected("auth") {
+		findings = append(findings, Finding{
+			Service: "auth",
+			Problem: "nhost/emails is missing, auth can't load its email templates",
+			Fix:     "restore the folder from version control or copy the default templates from https://github.com/nhost/hasura-auth/tree/main/email-templates", //nolint:lll
+		})
+	}
+
+	for _, s := range in.Statuses {
+		switch {
+		case s.Health == "unhealthy":
+			findings = append(findings, Finding{
+				Service: s.Service,
+				Problem: fmt.Sprintf("service %s is unhealthy", s.Service),
+				Fix:     fmt.Sprintf("check the logs with `nhost logs %s`", s.Service),
+			})
+		case s.State == "exited" && s.ExitCode != 0:
+			findings = append(findings, Finding{
+				Service: s.Service,
+				Problem: fmt.Sprintf("service %s exited with code %d", s.Service, s.ExitCode),
+				Fix:     fmt.Sprintf("check the logs with `nhost logs %s`", s.Service),
+			})
+		}
+	}
+
+	return findings
+}
+
+type diagnostics struct {
+	input  DiagnoseInput
+	health map[string]string
+}
+
+func collectDiagnostics( //nolint:funlen
+	ctx context.Context,
+	ce *clienv.CliEnv,
+	dc *dockercompose.DockerCompose,
+	upErr error,
+	httpPort, postgresPort uint,
+) diagnostics {
+	d := diagnostics{
+		input: DiagnoseInput{
+			Stderr:          "",
+			Services:        nil,
+			Statuses:        nil,
+			Logs:            map[string]string{},
+			HasuraVersion:   "",
+			PostgresVersion: "",
+			PGDataVersion:   "",
+			PGDataFolder:    dockercompose.PostgresDataFolder(ce.Path.DataFolder()),
+			EmailsFolder:    clienv.PathExists(filepath.Join(ce.Path.NhostFolder(), "emails")),
+			HTTPPort:        httpPort,
+			PostgresPort:    postgresPort,
+		},
+		health: map[string]string{},
+	}
+
+	if cfg, err := config.Validate(ce, "local"); err == nil {
+		if v := cfg.GetHasura().GetVersion(); v != nil {
+			d.input.HasuraVersion = *v
+		}
+		if v := cfg.GetPostgres().GetVersion(); v != nil {
+			d.input.PostgresVersion = *v
+		}
+	}
+
+	if services, err := dc.Services(); err == nil {
+		d.input.Services = services
+	}
+
+	if v, err := dockercompose.PostgresDataVersion(ce.Path.DataFolder()); err == nil {
+		d.input.PGDataVersion = v
+	}
+
+	var cmdErr *dockercompose.CommandError
+	if !errors.As(upErr, &cmdErr) {
+		return d
+	}
+	d.input.Stderr = cmdErr.Stderr
+
+	statuses, err := dc.Status(ctx)
+	if err != nil {
+		ce.Warnln("failed to get status of services: %s", err)
+		return d
+	}
+	d.input.Statuses = statuses
+
+	for _, s := range statuses {
+		logs, err := dc.ServiceLogs(ctx, s.Service, reportLogLines)
+		if err != nil {
+			ce.Warnln("failed to get logs of %s: %s", s.Service, err)
+		} else {
+			d.input.Logs[s.Service] = logs
+		}
+
+		if s.Failed() {
+			output, err := dc.HealthcheckOutput(ctx, s.Name)
+			if err != nil {
+				ce.Warnln("failed to get healthcheck of %s: %s", s.Service, err)
+			} else if output != "" {
+				d.health[s.Service] = output
+			}
+		}
+	}
+
+	return d
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func redact(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, secret, "[REDACTED]")
+	}
+	return s
+}
+
+func printDiagnostics(ce *clienv.CliEnv, d diagnostics, findings []Finding) {
+	// with --output json or yaml stdout is reserved for the result
+	w := ce.LogWriter()
+	for _, s := range d.input.Statuses {
+		if !s.Failed() {
+			continue
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Last logs of %s:\n", s.Service)
+		fmt.Fprintln(w, lastLines(d.input.Logs[s.Service], diagnoseLogLines))
+		if output, ok := d.health[s.Service]; ok {
+			fmt.Fprintf(w, "Healthcheck output of %s:\n", s.Service)
+			fmt.Fprintln(w, output)
+		}
+	}
+
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	ce.Warnln("Problems found:")
+	for _, f := range findings {
+		fmt.Fprintf(w, "- %s\n", f.Problem)
+		fmt.Fprintf(w, "  Suggested fix: %s\n", f.Fix)
+	}
+}
+
+func writeReport(
+	ce *clienv.CliEnv,
+	d diagnostics,
+	findings []Finding,
+	upErr error,
+) (string, error) {
+	var secrets []string
+	if s, err := config.Secrets(ce); err == nil {
+		for _, v := range s {
+			secrets = append(secrets, v.Value)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "date: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&sb, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&sb, "error: %s\n", upErr)
+	fmt.Fprintf(&sb, "hasura version: %s\n", d.input.HasuraVersion)
+	fmt.Fprintf(&sb, "postgres version: %s\n", d.input.PostgresVersion)
+	fmt.Fprintf(&sb, "pgdata version: %s\n", d.input.PGDataVersion)
+	fmt.Fprintf(&sb, "emails folder: %t\n", d.input.EmailsFolder)
+
+	sb.WriteString("\n## problems\n")
+	for _, f := range findings {
+		fmt.Fprintf(&sb, "- %s\n  fix: %s\n", f.Problem, f.Fix)
+	}
+
+	sb.WriteString("\n## docker compose output\n")
+	sb.WriteString(d.input.Stderr)
+
+	sb.WriteString("\n## services\n")
+	for _, s := range d.input.Statuses {
+		fmt.Fprintf(
+			&sb, "- %s: state=%s health=%s exit_code=%d\n",
+			s.Service, s.State, s.Health, s.ExitCode,
+		)
+	}
+
+	services := make([]string, 0, len(d.health))
+	for service := range d.health {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		fmt.Fprintf(&sb, "\n## healthcheck %s\n%s\n", service, d.health[service])
+	}
+
+	services = make([]string, 0, len(d.input.Logs))
+	for service := range d.input.Logs {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		fmt.Fprintf(&sb, "\n## logs %s\n%s\n", service, d.input.Logs[service])
+	}
+
+	if err := os.MkdirAll(ce.Path.DotNhostFolder(), 0o755); err != nil { //nolint:gomnd
+		return "", fmt.Errorf("failed to create .nhost folder: %w", err)
+	}
+
+	path := filepath.Join(
+		ce.Path.DotNhostFolder(),
+		fmt.Sprintf("up-report-%s.log", time.Now().Format("20060102-150405")),
+	)
+	if err := os.WriteFile(path, []byte(redact(sb.String(), secrets)), 0o600); err != nil { //nolint:gomnd
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return path, nil
+}
+
+// diagnose analyzes why the development environment failed to start, prints
+// the problems found along with a suggested fix and writes a full report.
+func diagnose(
+	ctx context.Context,
+	ce *clienv.CliEnv,
+	dc *dockercompose.DockerCompose,
+	upErr error,
+	httpPort, postgresPort uint,
+) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	d := collectDiagnostics(ctx, ce, dc, upErr, httpPort, postgresPort)
+	findings := Diagnose(d.input)
+	printDiagnostics(ce, d, findings)
+
+	path, err := writeReport(ce, d, findings, upErr)
+	if err != nil {
+		ce.Warnln("%s", err)
+		return
+	}
+	fmt.Fprintln(ce.LogWriter())
+	ce.Infoln("A full report was written to %s, attach it when reporting a bug", path)
+}
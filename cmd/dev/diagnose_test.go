@@ -0,0 +1,155 @@
+package dev_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/cmd/dev"
+	"github.com/nhost/cli/dockercompose"
+)
+
+func TestDiagnose(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	healthy := dev.DiagnoseInput{
+		Stderr:          "",
+		Services:        nil,
+		Statuses:        nil,
+		Logs:            map[string]string{},
+		HasuraVersion:   "v2.24.1-ce",
+		PostgresVersion: "14.6-20230406-2",
+		PGDataVersion:   "14",
+		PGDataFolder:    ".nhost/data/main/db/pgdata",
+		EmailsFolder:    true,
+		HTTPPort:        443,
+		PostgresPort:    5432,
+	}
+
+	cases := []struct {
+		name     string
+		input    func() dev.DiagnoseInput
+		expected []dev.Finding
+	}{
+		{
+			name:     "nothing found",
+			input:    func() dev.DiagnoseInput { return healthy },
+			expected: nil,
+		},
+		{
+			name: "port in use",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.Stderr = "Error response from daemon: driver failed programming external connectivity on endpoint local-traefik-1: Bind for 0.0.0.0:443 failed: port is already allocated\n" //nolint:lll
+				return in
+			},
+			expected: []dev.Finding{
+				{
+					Service: "",
+					Problem: "port 443 is already in use",
+					Fix:     "stop the process using it (i.e. `lsof -i :443`) or choose another port with --http-port",
+				},
+			},
+		},
+		{
+			name: "old hasura",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.HasuraVersion = "v2.15.2"
+				return in
+			},
+			expected: []dev.Finding{
+				{
+					Service: "graphql",
+					Problem: "hasura version v2.15.2 is older than the minimum supported version v2.18.0",
+					Fix:     "set hasura.version to v2.18.0 or newer in nhost/nhost.toml",
+				},
+			},
+		},
+		{
+			name: "incompatible pgdata",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.PostgresVersion = "15.2-20230406-1"
+				return in
+			},
+			expected: []dev.Finding{
+				{
+					Service: "postgres",
+					Problem: "the database in .nhost/data/main/db/pgdata was created with postgres 14 but postgres 15.2-20230406-1 is configured", //nolint:lll
+					Fix:     "run `nhost dev db upgrade` to upgrade the data or set postgres.version back to a 14.x version",                      //nolint:lll
+				},
+			},
+		},
+		{
+			name: "incompatible pgdata from logs",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.PGDataVersion = ""
+				in.PostgresVersion = "15.2-20230406-1"
+				in.Logs = map[string]string{
+					"postgres": "FATAL:  database files are incompatible with server\nDETAIL:  The data directory was initialized by PostgreSQL version 14, which is not compatible with this version 15.2.", //nolint:lll
+				}
+				return in
+			},
+			expected: []dev.Finding{
+				{
+					Service: "postgres",
+					Problem: "the database in .nhost/data/main/db/pgdata was created with postgres 14 but postgres 15.2-20230406-1 is configured", //nolint:lll
+					Fix:     "run `nhost dev db upgrade` to upgrade the data or set postgres.version back to a 14.x version",                      //nolint:lll
+				},
+			},
+		},
+		{
+			name: "missing emails without auth",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.EmailsFolder = false
+				in.Services = []string{"graphql", "postgres"}
+				return in
+			},
+			expected: nil,
+		},
+		{
+			name: "missing emails and unhealthy service",
+			input: func() dev.DiagnoseInput {
+				in := healthy
+				in.EmailsFolder = false
+				in.Statuses = []dockercompose.ServiceStatus{
+					{Name: "local-auth-1", Service: "auth", State: "running", Health: "unhealthy", ExitCode: 0},
+					{Name: "local-postgres-1", Service: "postgres", State: "running", Health: "healthy", ExitCode: 0},
+					{Name: "local-minio-1", Service: "minio", State: "exited", Health: "", ExitCode: 1},
+				}
+				return in
+			},
+			expected: []dev.Finding{
+				{
+					Service: "auth",
+					Problem: "nhost/emails is missing, auth can't load its email templates",
+					Fix:     "restore the folder from version control or copy the default templates from https://github.com/nhost/hasura-auth/tree/main/email-templates", //nolint:lll
+				},
+				{
+					Service: "auth",
+					Problem: "service auth is unhealthy",
+					Fix:     "check the logs with `nhost logs auth`",
+				},
+				{
+					Service: "minio",
+					Problem: "service minio exited with code 1",
+					Fix:     "check the logs with `nhost logs minio`",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := dev.Diagnose(tc.input())
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("findings mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ func CommandDown() *cli.Command {
 func commandDown(cCtx *cli.Context) error {
 	ce := clienv.FromCLI(cCtx)
 
+	stopApp(ce)
+
 	dc := dockercompose.New(ce.Path.WorkingDir(), ce.Path.DockerCompose(), ce.ProjectName())
 
 	if err := dc.Stop(cCtx.Context); err != nil {
@@ -12,11 +12,23 @@ func CommandLogs() *cli.Command {
 		Aliases:         []string{},
 		Usage:           "Show logs from local development environment",
 		Action:          commandLogs,
+		BashComplete:    clienv.Complete(nil, serviceNames),
 		Flags:           []cli.Flag{},
 		SkipFlagParsing: true,
 	}
 }
 
+// serviceNames suggests the services of the local development environment.
+func serviceNames(cCtx *cli.Context) []string {
+	ce := clienv.FromCLI(cCtx)
+	dc := dockercompose.New(ce.Path.WorkingDir(), ce.Path.DockerCompose(), ce.ProjectName())
+	services, err := dc.Services()
+	if err != nil {
+		return nil
+	}
+	return services
+}
+
 func commandLogs(cCtx *cli.Context) error {
 	ce := clienv.FromCLI(cCtx)
 
@@ -0,0 +1,219 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/jwt"
+	"github.com/nhost/cli/seeds"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagRole       = "role"
+	flagUser       = "user"
+	flagUserID     = "user-id"
+	flagClaims     = "claims"
+	flagExpires    = "expires"
+	flagPrivateKey = "private-key"
+)
+
+func CommandToken() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "token",
+		Aliases: []string{},
+		Usage:   "Mint a JWT signed with the project's JWT secret",
+		Action:  commandToken,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagRole,
+				Usage: "Default role, defaults to the user's or auth.user.roles.default",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagUser,
+				Usage: "Email of a user in the auth seeds to resolve custom claims against",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagUserID,
+				Usage: "User ID",
+			},
+			&cli.StringSliceFlag{ //nolint:exhaustruct
+				Name:  flagClaims,
+				Usage: "Extra hasura claims in the form key=value, x-hasura- is prepended if missing. Overrides custom claims", //nolint:lll
+			},
+			&cli.DurationFlag{ //nolint:exhaustruct
+				Name:  flagExpires,
+				Usage: "Token lifetime, defaults to auth.session.accessToken.expiresIn",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagPrivateKey,
+				Usage:     "Path to the PEM private key, required for RS* secrets",
+				TakesFile: true,
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:      "inspect",
+				ArgsUsage: "JWT",
+				Aliases:   []string{},
+				Usage:     "Decode a JWT and verify it against the project's JWT secret",
+				Action:    commandTokenInspect,
+			},
+		},
+	}
+}
+
+func commandToken(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	cfg, err := config.Validate(ce, "local")
+	if err != nil {
+		return fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	extra, err := jwt.ParseClaims(cCtx.StringSlice(flagClaims))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var privateKey []byte
+	if cCtx.String(flagPrivateKey) != "" {
+		privateKey, err = os.ReadFile(cCtx.String(flagPrivateKey))
+		if err != nil {
+			return fmt.Errorf("failed to read private key: %w", err)
+		}
+	}
+
+	role := cCtx.String(flagRole)
+	user := map[string]any{}
+	if cCtx.String(flagUser) != "" {
+		fixture, err := userFixture(ce.Path.AuthSeeds(), cCtx.String(flagUser))
+		if err != nil {
+			return err
+		}
+		if user, err = userData(fixture); err != nil {
+			return err
+		}
+		if role == "" {
+			role = fixture.DefaultRole
+		}
+	}
+	if cCtx.String(flagUserID) != "" {
+		user["id"] = cCtx.String(flagUserID)
+	}
+
+	token, err := jwt.Mint(
+		cfg,
+		role,
+		cCtx.String(flagUserID),
+		user,
+		extra,
+		cCtx.Duration(flagExpires),
+		privateKey,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mint token: %w", err)
+	}
+
+	ce.Println("%s", token)
+	return nil
+}
+
+// userFixture returns the user with the given email from the auth seeds.
+func userFixture(path, email string) (seeds.User, error) {
+	users, err := seeds.LoadUsers(path)
+	if err != nil {
+		return seeds.User{}, err //nolint:wrapcheck
+	}
+	for _, u := range users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return seeds.User{}, fmt.Errorf("user %s not found in %s", email, path) //nolint:goerr113
+}
+
+// userData returns the fields of the user custom claims are resolved against.
+func userData(user seeds.User) (map[string]any, error) {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user: %w", err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+	delete(data, "password")
+	return data, nil
+}
+
+// TokenInfo is the decoded token as printed by `dev token inspect`.
+type TokenInfo struct {
+	Header  map[string]any `json:"header"`
+	Claims  map[string]any `json:"claims"`
+	Expires string         `json:"expires,omitempty"`
+}
+
+func commandTokenInspect(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("invalid number of arguments") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	token, err := jwt.Parse(cCtx.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	info := TokenInfo{
+		Header:  token.Header,
+		Claims:  token.Claims,
+		Expires: "",
+	}
+	if exp, ok := token.Claims["exp"].(float64); ok {
+		info.Expires = time.Unix(int64(exp), 0).Format(time.RFC3339)
+	}
+
+	header, err := json.MarshalIndent(info.Header, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal header: %w", err)
+	}
+	claims, err := json.MarshalIndent(info.Claims, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal claims: %w", err)
+	}
+
+	if err := ce.PrintData(info, func() {
+		ce.Println("Header:")
+		ce.Println("%s", header)
+		ce.Println("Claims:")
+		ce.Println("%s", claims)
+		if info.Expires != "" {
+			ce.Println("Expires: %s", info.Expires)
+		}
+	}); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	cfg, err := config.Validate(ce, "local")
+	if err != nil {
+		return fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	secret, err := jwt.Secret(cfg)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := token.Verify(secret); err != nil {
+		return fmt.Errorf("token is not valid: %w", err)
+	}
+
+	ce.Infoln("Token is valid")
+	return nil
+}
@@ -0,0 +1,216 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/nhost/cli/seeds"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagEmail         = "email"
+	flagPassword      = "password"
+	flagDisplayName   = "display-name"
+	flagDefaultRole   = "default-role"
+	flagAllowedRoles  = "allowed-roles"
+	flagMetadata      = "metadata"
+	flagEmailVerified = "email-verified"
+)
+
+func localFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.UintFlag{ //nolint:exhaustruct
+			Name:    flagHTTPPort,
+			Usage:   "HTTP port the local development environment listens on",
+			Value:   defaultHTTPPort,
+			EnvVars: []string{"NHOST_HTTP_PORT"},
+		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:    flagDisableTLS,
+			Usage:   "Local development environment was started with TLS disabled",
+			Value:   false,
+			EnvVars: []string{"NHOST_DISABLE_TLS"},
+		},
+	}
+}
+
+func CommandUsers() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "users",
+		Aliases: []string{},
+		Usage:   "Manage users of the local development environment",
+		Subcommands: []*cli.Command{
+			{
+				Name:    "list",
+				Aliases: []string{},
+				Usage:   "List users",
+				Action:  commandUsersList,
+				Flags:   localFlags(),
+			},
+			{
+				Name:    "create",
+				Aliases: []string{},
+				Usage:   "Create a user, roles default to auth.user.roles",
+				Action:  commandUsersCreate,
+				Flags: append(
+					localFlags(),
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:     flagEmail,
+						Usage:    "Email address",
+						Required: true,
+					},
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:     flagPassword,
+						Usage:    "Password",
+						Required: true,
+					},
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:  flagDisplayName,
+						Usage: "Display name, defaults to the email address",
+					},
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:  flagDefaultRole,
+						Usage: "Default role",
+					},
+					&cli.StringSliceFlag{ //nolint:exhaustruct
+						Name:  flagAllowedRoles,
+						Usage: "Allowed roles",
+					},
+					&cli.StringFlag{ //nolint:exhaustruct
+						Name:  flagMetadata,
+						Usage: "User metadata as a JSON object",
+					},
+					&cli.BoolFlag{ //nolint:exhaustruct
+						Name:  flagEmailVerified,
+						Usage: "Mark the email as verified",
+						Value: true,
+					},
+				),
+			},
+			{
+				Name:      "delete",
+				ArgsUsage: "EMAIL",
+				Aliases:   []string{},
+				Usage:     "Delete a user",
+				Action:    commandUsersDelete,
+				Flags:     localFlags(),
+			},
+		},
+	}
+}
+
+func newUsersClient(cfg *model.ConfigConfig, httpPort uint, useTLS bool) *seeds.Users {
+	return seeds.NewUsers(
+		dockercompose.URL("auth", httpPort, useTLS)+"/v1",
+		dockercompose.URL("graphql", httpPort, useTLS)+"/v1",
+		cfg.GetHasura().GetAdminSecret(),
+	)
+}
+
+func usersClientFromCLI(cCtx *cli.Context) (*clienv.CliEnv, *model.ConfigConfig, *seeds.Users, error) {
+	ce := clienv.FromCLI(cCtx)
+
+	cfg, err := config.Validate(ce, "local")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	return ce, cfg, newUsersClient(
+		cfg, cCtx.Uint(flagHTTPPort), !cCtx.Bool(flagDisableTLS),
+	), nil
+}
+
+func commandUsersList(cCtx *cli.Context) error {
+	ce, _, cl, err := usersClientFromCLI(cCtx)
+	if err != nil {
+		return err
+	}
+
+	users, err := cl.List(cCtx.Context)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return ce.PrintData(users, func() { //nolint:wrapcheck
+		id := clienv.Column{Header: "ID", Rows: make([]string, 0)}
+		email := clienv.Column{Header: "Email", Rows: make([]string, 0)}
+		defaultRole := clienv.Column{Header: "Default Role", Rows: make([]string, 0)}
+		allowedRoles := clienv.Column{Header: "Allowed Roles", Rows: make([]string, 0)}
+		verified := clienv.Column{Header: "Verified", Rows: make([]string, 0)}
+
+		for _, user := range users {
+			id.Rows = append(id.Rows, user.ID)
+			email.Rows = append(email.Rows, user.Email)
+			defaultRole.Rows = append(defaultRole.Rows, user.DefaultRole)
+			allowedRoles.Rows = append(allowedRoles.Rows, strings.Join(user.AllowedRoles(), ","))
+			verified.Rows = append(verified.Rows, fmt.Sprintf("%t", user.EmailVerified))
+		}
+
+		ce.Println(clienv.Table(id, email, defaultRole, allowedRoles, verified))
+	})
+}
+
+func commandUsersCreate(cCtx *cli.Context) error {
+	ce, cfg, cl, err := usersClientFromCLI(cCtx)
+	if err != nil {
+		return err
+	}
+
+	var metadata map[string]any
+	if cCtx.String(flagMetadata) != "" {
+		if err := json.Unmarshal([]byte(cCtx.String(flagMetadata)), &metadata); err != nil {
+			return fmt.Errorf("failed to parse metadata: %w", err)
+		}
+	}
+
+	user := seeds.User{
+		Email:         cCtx.String(flagEmail),
+		Password:      cCtx.String(flagPassword),
+		DisplayName:   cCtx.String(flagDisplayName),
+		Locale:        "",
+		DefaultRole:   cCtx.String(flagDefaultRole),
+		AllowedRoles:  cCtx.StringSlice(flagAllowedRoles),
+		Metadata:      metadata,
+		EmailVerified: cCtx.Bool(flagEmailVerified),
+	}.WithDefaults(cfg.GetAuth().GetUser().GetRoles())
+
+	info, err := cl.Create(cCtx.Context, user, cfg.GetAuth().GetUser().GetRoles())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("User %s created with id %s", info.Email, info.ID)
+	return nil
+}
+
+func commandUsersDelete(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("invalid number of arguments") //nolint:goerr113
+	}
+
+	ce, _, cl, err := usersClientFromCLI(cCtx)
+	if err != nil {
+		return err
+	}
+
+	user, err := cl.Get(cCtx.Context, cCtx.Args().First())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if user == nil {
+		return fmt.Errorf("user %s not found", cCtx.Args().First()) //nolint:goerr113
+	}
+
+	if err := cl.Delete(cCtx.Context, user.ID); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("User %s deleted", user.Email)
+	return nil
+}
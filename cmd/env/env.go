@@ -0,0 +1,14 @@
+package env
+
+import "github.com/urfave/cli/v2"
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "env",
+		Aliases: []string{},
+		Usage:   "Generate environment files for your applications",
+		Subcommands: []*cli.Command{
+			CommandExport(),
+		},
+	}
+}
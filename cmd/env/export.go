@@ -0,0 +1,199 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagFormat     = "format"
+	flagTarget     = "target"
+	flagPrefix     = "prefix"
+	flagOut        = "out"
+	flagHTTPPort   = "http-port"
+	flagDisableTLS = "disable-tls"
+)
+
+const (
+	formatDotenv = "dotenv"
+	formatJSON   = "json"
+	formatTS     = "ts"
+)
+
+const (
+	targetLocal     = "local"
+	defaultHTTPPort = 443
+)
+
+func CommandExport() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "export",
+		Aliases: []string{},
+		Usage:   "Export the subdomain, region and service URLs of a project",
+		Description: `Only values safe to expose to frontend applications are exported. For instance:
+
+  nhost env export --prefix NEXT_PUBLIC_ > .env.local
+  nhost env export --target my-subdomain --format ts --out src/nhost-env.ts`,
+		Action: commandExport,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagFormat,
+				Usage: "Output format, one of dotenv, json or ts",
+				Value: formatDotenv,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagTarget,
+				Usage: "Either local for the local development environment or a cloud project's subdomain",
+				Value: targetLocal,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagPrefix,
+				Usage: "Prefix to prepend to the variables, for instance NEXT_PUBLIC_",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagOut,
+				Usage:     "Write the variables to this file instead of stdout",
+				TakesFile: true,
+			},
+			&cli.UintFlag{ //nolint:exhaustruct
+				Name:    flagHTTPPort,
+				Usage:   "HTTP port the local development environment listens on",
+				Value:   defaultHTTPPort,
+				EnvVars: []string{"NHOST_HTTP_PORT"},
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:    flagDisableTLS,
+				Usage:   "Local development environment was started with TLS disabled",
+				Value:   false,
+				EnvVars: []string{"NHOST_DISABLE_TLS"},
+			},
+		},
+	}
+}
+
+// Variables returns the variables describing a project. Service URLs are
+// built with url, which receives the name of the service.
+func Variables(subdomain, region string, url func(service string) string) map[string]string {
+	return map[string]string{
+		"NHOST_SUBDOMAIN":     subdomain,
+		"NHOST_REGION":        region,
+		"NHOST_AUTH_URL":      url("auth") + "/v1",
+		"NHOST_GRAPHQL_URL":   url("graphql") + "/v1",
+		"NHOST_STORAGE_URL":   url("storage") + "/v1",
+		"NHOST_FUNCTIONS_URL": url("functions") + "/v1",
+	}
+}
+
+// LocalVariables returns the variables of the local development environment.
+func LocalVariables(httpPort uint, useTLS bool) map[string]string {
+	return Variables(targetLocal, "", func(service string) string {
+		return dockercompose.URL(service, httpPort, useTLS)
+	})
+}
+
+// CloudVariables returns the variables of a cloud project.
+func CloudVariables(subdomain, region, domain string) map[string]string {
+	return Variables(subdomain, region, func(service string) string {
+		return fmt.Sprintf("https://%s.%s.%s.%s", subdomain, service, region, domain)
+	})
+}
+
+func sortedKeys(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s) //nolint:errchkjson
+	return string(b)
+}
+
+// Format renders the variables in the given format with their names prefixed.
+func Format(vars map[string]string, format, prefix string) (string, error) {
+	var b strings.Builder
+	switch format {
+	case formatDotenv:
+		for _, k := range sortedKeys(vars) {
+			v := vars[k]
+			if strings.ContainsAny(v, " #\"'\n") {
+				v = quote(v)
+			}
+			fmt.Fprintf(&b, "%s%s=%s\n", prefix, k, v)
+		}
+	case formatJSON:
+		prefixed := make(map[string]string, len(vars))
+		for k, v := range vars {
+			prefixed[prefix+k] = v
+		}
+		out, err := json.MarshalIndent(prefixed, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal variables: %w", err)
+		}
+		b.Write(out)
+		b.WriteString("\n")
+	case formatTS:
+		for _, k := range sortedKeys(vars) {
+			fmt.Fprintf(&b, "export const %s%s = %s;\n", prefix, k, quote(vars[k]))
+		}
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format) //nolint:goerr113
+	}
+
+	return b.String(), nil
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case formatDotenv, formatJSON, formatTS:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s", format) //nolint:goerr113
+	}
+}
+
+func commandExport(cCtx *cli.Context) error {
+	// before resolving the target, which might ask to login or link
+	if err := validateFormat(cCtx.String(flagFormat)); err != nil {
+		return err
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	var vars map[string]string
+	if target := cCtx.String(flagTarget); target == targetLocal {
+		vars = LocalVariables(cCtx.Uint(flagHTTPPort), !cCtx.Bool(flagDisableTLS))
+	} else {
+		proj, err := ce.GetAppInfo(cCtx.Context, target)
+		if err != nil {
+			return fmt.Errorf("failed to get app info: %w", err)
+		}
+		vars = CloudVariables(proj.Subdomain, proj.Region.AwsName, ce.Domain())
+	}
+
+	out, err := Format(vars, cCtx.String(flagFormat), cCtx.String(flagPrefix))
+	if err != nil {
+		return err
+	}
+
+	if cCtx.String(flagOut) == "" {
+		ce.Println("%s", strings.TrimSuffix(out, "\n"))
+		return nil
+	}
+
+	if err := os.WriteFile(cCtx.String(flagOut), []byte(out), 0o644); err != nil { //nolint:gosec,gomnd
+		return fmt.Errorf("failed to write variables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/cmd/env"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		vars     map[string]string
+		format   string
+		prefix   string
+		expected string
+	}{
+		{
+			name:   "dotenv local",
+			vars:   env.LocalVariables(443, true),
+			format: "dotenv",
+			prefix: "NEXT_PUBLIC_",
+			expected: `NEXT_PUBLIC_NHOST_AUTH_URL=https://local.auth.nhost.run/v1
+NEXT_PUBLIC_NHOST_FUNCTIONS_URL=https://local.functions.nhost.run/v1
+NEXT_PUBLIC_NHOST_GRAPHQL_URL=https://local.graphql.nhost.run/v1
+NEXT_PUBLIC_NHOST_REGION=
+NEXT_PUBLIC_NHOST_STORAGE_URL=https://local.storage.nhost.run/v1
+NEXT_PUBLIC_NHOST_SUBDOMAIN=local
+`,
+		},
+		{
+			name:   "json cloud",
+			vars:   env.CloudVariables("asdasd", "eu-central-1", "nhost.run"),
+			format: "json",
+			prefix: "",
+			expected: `{
+  "NHOST_AUTH_URL": "https://asdasd.auth.eu-central-1.nhost.run/v1",
+  "NHOST_FUNCTIONS_URL": "https://asdasd.functions.eu-central-1.nhost.run/v1",
+  "NHOST_GRAPHQL_URL": "https://asdasd.graphql.eu-central-1.nhost.run/v1",
+  "NHOST_REGION": "eu-central-1",
+  "NHOST_STORAGE_URL": "https://asdasd.storage.eu-central-1.nhost.run/v1",
+  "NHOST_SUBDOMAIN": "asdasd"
+}
+`,
+		},
+		{
+			name:   "ts local without tls",
+			vars:   env.LocalVariables(1337, false),
+			format: "ts",
+			prefix: "VITE_",
+			expected: `export const VITE_NHOST_AUTH_URL = "http://local.auth.nhost.run:1337/v1";
+export const VITE_NHOST_FUNCTIONS_URL = "http://local.functions.nhost.run:1337/v1";
+export const VITE_NHOST_GRAPHQL_URL = "http://local.graphql.nhost.run:1337/v1";
+export const VITE_NHOST_REGION = "";
+export const VITE_NHOST_STORAGE_URL = "http://local.storage.nhost.run:1337/v1";
+export const VITE_NHOST_SUBDOMAIN = "local";
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := env.Format(tc.vars, tc.format, tc.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
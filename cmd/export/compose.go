@@ -0,0 +1,366 @@
+package export
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/cmd/env"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagOut          = "out"
+	flagDomain       = "domain"
+	flagACMEEmail    = "acme-email"
+	flagPlaceholders = "placeholders"
+)
+
+const placeholder = "FIXME"
+
+// projectFiles are the files in the root of the project functions need.
+var projectFiles = []string{ //nolint:gochecknoglobals
+	"package.json",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"tsconfig.json",
+}
+
+func CommandCompose() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "compose",
+		Aliases: []string{},
+		Usage:   "Export a docker compose bundle to self-host the project",
+		Description: `The bundle includes everything needed to run the project with plain
+"docker compose up" on a server: the compose file, traefik's configuration,
+email templates, migrations, metadata and a .env file with the secrets.
+Exporting again to the same folder keeps the credentials already in its .env
+file. Services are exposed at <service>.<domain>, for instance:
+
+  nhost export compose --out deploy --domain example.com --acme-email admin@example.com
+  cd deploy && docker compose up -d`,
+		Action: commandCompose,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagOut,
+				Usage:     "Folder to write the bundle to",
+				Required:  true,
+				TakesFile: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:     flagDomain,
+				Usage:    "Domain to expose the services at, for instance auth.<domain>",
+				Required: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagACMEEmail,
+				Usage: "Enable TLS with Let's Encrypt certificates registered with this email",
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:  flagPlaceholders,
+				Usage: "Write placeholders instead of the values in .secrets to the .env file",
+				Value: false,
+			},
+		},
+	}
+}
+
+// secretReferences returns the secrets with their values replaced by a
+// reference to the variable of the same name in the .env file.
+func secretReferences(secrets model.Secrets) model.Secrets {
+	refs := make(model.Secrets, len(secrets))
+	for i, s := range secrets {
+		refs[i] = &model.ConfigEnvironmentVariable{
+			Name:  s.Name,
+			Value: fmt.Sprintf("${%s}", s.Name),
+		}
+	}
+	return refs
+}
+
+func randomValue() (string, error) {
+	b := make([]byte, 16) //nolint:gomnd
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ReadDotenv reads the variables of a .env file written by Dotenv. If the file
+// doesn't exist it returns no variables.
+func ReadDotenv(path string) (map[string]string, error) {
+	vars := map[string]string{}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return vars, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid line %d in %s", i+1, path) //nolint:goerr113
+		}
+		if strings.HasPrefix(v, `"`) {
+			if v, err = strconv.Unquote(v); err != nil {
+				return nil, fmt.Errorf("invalid value on line %d in %s: %w", i+1, path, err)
+			}
+		}
+		vars[k] = v
+	}
+
+	return vars, nil
+}
+
+// Dotenv returns the contents of the .env file of the bundle. Variables in
+// existing, the .env file of a previous export, are kept so credentials of
+// services with existing data don't change. Secrets are updated unless
+// placeholders are requested.
+func Dotenv(
+	existing map[string]string,
+	secrets model.Secrets,
+	placeholders bool,
+) (string, error) {
+	vars := make(map[string]string, len(existing)+len(secrets))
+	for k, v := range existing {
+		vars[k] = v
+	}
+
+	for _, name := range dockercompose.ExportVariables {
+		if _, ok := vars[name]; ok {
+			continue
+		}
+		v, err := randomValue()
+		if err != nil {
+			return "", err
+		}
+		vars[name] = v
+	}
+
+	if err := dockercompose.ValidatePostgresPassword(vars["POSTGRES_PASSWORD"]); err != nil {
+		return "", fmt.Errorf("invalid .env: %w", err)
+	}
+
+	for _, s := range secrets {
+		switch _, ok := vars[s.Name]; {
+		case !placeholders:
+			vars[s.Name] = s.Value
+		case !ok:
+			vars[s.Name] = placeholder
+		}
+	}
+
+	return env.Format(vars, "dotenv", "") //nolint:wrapcheck
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	return nil
+}
+
+// copyDir replaces dst with a copy of the folder src skipping node_modules so
+// files removed from src don't linger in dst. If src doesn't exist an empty
+// folder is created so bind mounts still work.
+func copyDir(src, dst string) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", dst, err)
+	}
+
+	if !clienv.PathExists(src) {
+		if err := os.MkdirAll(dst, 0o755); err != nil { //nolint:gomnd
+			return fmt.Errorf("failed to create folder: %w", err)
+		}
+		return nil
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error { //nolint:wrapcheck
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+
+		switch {
+		case d.IsDir() && d.Name() == "node_modules":
+			return filepath.SkipDir
+		case d.IsDir():
+			if err := os.MkdirAll(filepath.Join(dst, rel), 0o755); err != nil { //nolint:gomnd
+				return fmt.Errorf("failed to create folder: %w", err)
+			}
+			return nil
+		default:
+			return copyFile(path, filepath.Join(dst, rel))
+		}
+	})
+}
+
+func writeFile(path, content string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// CopyProject copies the files of the project the services need to out,
+// replacing the ones copied by previous exports.
+func CopyProject(ce *clienv.CliEnv, out string) error {
+	for _, folder := range []string{"emails", "migrations", "metadata"} {
+		if err := copyDir(
+			filepath.Join(ce.Path.NhostFolder(), folder),
+			filepath.Join(out, "nhost", folder),
+		); err != nil {
+			return err
+		}
+	}
+
+	functionsFolder := filepath.Join(ce.Path.Root(), "functions")
+	if !clienv.PathExists(functionsFolder) {
+		if err := os.RemoveAll(filepath.Join(out, "project", "functions")); err != nil {
+			return fmt.Errorf("failed to remove functions: %w", err)
+		}
+		return nil
+	}
+
+	if err := copyDir(functionsFolder, filepath.Join(out, "project", "functions")); err != nil {
+		return err
+	}
+	for _, f := range projectFiles {
+		if !clienv.PathExists(filepath.Join(ce.Path.Root(), f)) {
+			continue
+		}
+		if err := copyFile(
+			filepath.Join(ce.Path.Root(), f), filepath.Join(out, "project", f),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func commandCompose(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+	out := cCtx.String(flagOut)
+
+	secrets, err := config.Secrets(ce)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	cfg, err := config.ValidateWithSecrets(ce, "local", secretReferences(secrets))
+	if err != nil {
+		return fmt.Errorf(
+			"failed to validate config, secrets used in non-string fields can't be exported: %w",
+			err,
+		)
+	}
+
+	opts := dockercompose.ExportOptions{
+		Domain:    cCtx.String(flagDomain),
+		ACMEEmail: cCtx.String(flagACMEEmail),
+		Functions: clienv.PathExists(filepath.Join(ce.Path.Root(), "functions")),
+	}
+
+	composeFile, err := dockercompose.ExportComposeFile(cfg, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate docker-compose.yaml: %w", err)
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create output folder: %w", err)
+	}
+
+	dc := dockercompose.New(out, filepath.Join(out, "docker-compose.yaml"), "")
+	if err := dc.WriteComposeFile(composeFile); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := writeFile(
+		filepath.Join(out, "traefik", "traefik.yaml"),
+		dockercompose.ExportTraefikConfig(opts),
+		0o644, //nolint:gomnd
+	); err != nil {
+		return err
+	}
+
+	if err := writeFile(
+		filepath.Join(out, "postgres", "pg_hba.conf"), dockercompose.ExportPgHba, 0o644, //nolint:gomnd
+	); err != nil {
+		return err
+	}
+	if err := writeFile(
+		filepath.Join(out, "postgres", "initdb.sh"),
+		dockercompose.ExportPostgresInitScript(),
+		0o755, //nolint:gomnd
+	); err != nil {
+		return err
+	}
+
+	existing, err := ReadDotenv(filepath.Join(out, ".env"))
+	if err != nil {
+		return err
+	}
+	dotEnv, err := Dotenv(existing, secrets, cCtx.Bool(flagPlaceholders))
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(out, ".env"), dotEnv, 0o600); err != nil { //nolint:gomnd
+		return err
+	}
+
+	if err := CopyProject(ce, out); err != nil {
+		return err
+	}
+
+	ce.Infoln("Bundle written to %s", out)
+	if cCtx.Bool(flagPlaceholders) {
+		ce.Warnln("Replace the %s placeholders in %s before starting it", placeholder, filepath.Join(out, ".env"))
+	}
+	ce.Infoln("Point *.%s to your server and run `docker compose up -d` from %s", opts.Domain, out)
+
+	return nil
+}
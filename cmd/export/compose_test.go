@@ -0,0 +1,229 @@
+package export_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/export"
+	"github.com/nhost/cli/dockercompose"
+)
+
+func TestDotenv(t *testing.T) {
+	t.Parallel()
+
+	secrets := model.Secrets{
+		{Name: "HASURA_GRAPHQL_ADMIN_SECRET", Value: "nhost-admin-secret"},
+		{Name: "GRAPHITE_WEBHOOK_SECRET", Value: "with spaces"},
+	}
+
+	cases := []struct {
+		name         string
+		existing     map[string]string
+		placeholders bool
+		expected     map[string]string
+	}{
+		{
+			name: "keeps existing credentials",
+			existing: map[string]string{
+				"MINIO_ROOT_PASSWORD":         "minio-password",
+				"MINIO_ROOT_USER":             "minio-user",
+				"POSTGRES_PASSWORD":           "postgres-password",
+				"HASURA_GRAPHQL_ADMIN_SECRET": "old",
+				"CUSTOM":                      "value",
+			},
+			placeholders: false,
+			expected: map[string]string{
+				"MINIO_ROOT_PASSWORD":         "minio-password",
+				"MINIO_ROOT_USER":             "minio-user",
+				"POSTGRES_PASSWORD":           "postgres-password",
+				"HASURA_GRAPHQL_ADMIN_SECRET": "nhost-admin-secret",
+				"GRAPHITE_WEBHOOK_SECRET":     "with spaces",
+				"CUSTOM":                      "value",
+			},
+		},
+		{
+			name: "placeholders keep replaced values",
+			existing: map[string]string{
+				"MINIO_ROOT_PASSWORD":         "minio-password",
+				"MINIO_ROOT_USER":             "minio-user",
+				"POSTGRES_PASSWORD":           "postgres-password",
+				"HASURA_GRAPHQL_ADMIN_SECRET": "replaced",
+			},
+			placeholders: true,
+			expected: map[string]string{
+				"MINIO_ROOT_PASSWORD":         "minio-password",
+				"MINIO_ROOT_USER":             "minio-user",
+				"POSTGRES_PASSWORD":           "postgres-password",
+				"HASURA_GRAPHQL_ADMIN_SECRET": "replaced",
+				"GRAPHITE_WEBHOOK_SECRET":     "FIXME",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := export.Dotenv(tc.existing, secrets, tc.placeholders)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := export.ReadDotenv(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDotenvGeneratesMissingCredentials(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	got, err := export.ReadDotenv(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := export.Dotenv(got, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := export.ReadDotenv(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range dockercompose.ExportVariables {
+		if first[name] == "" {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+
+	content, err = export.Dotenv(first, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := export.ReadDotenv(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("credentials changed on re-export: %s", diff)
+	}
+}
+
+func listFiles(t *testing.T, root string) []string {
+	t.Helper()
+
+	var files []string
+	if err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestCopyProjectRemovesStaleFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	out := t.TempDir()
+
+	nhostFolder := filepath.Join(root, "nhost")
+	for _, f := range []string{
+		"nhost/migrations/default/1_init/up.sql",
+		"nhost/migrations/default/2_todos/up.sql",
+		"nhost/metadata/version.yaml",
+		"functions/hello.ts",
+	} {
+		writeTestFile(t, filepath.Join(root, f))
+	}
+	writeTestFile(t, filepath.Join(out, ".env"))
+
+	ce := clienv.New(
+		io.Discard,
+		io.Discard,
+		clienv.NewPathStructure(root, root, filepath.Join(root, ".nhost"), "", nhostFolder),
+		"local.nhost.run",
+		"test",
+	)
+
+	if err := export.CopyProject(ce, out); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, "nhost/migrations/default/2_todos")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "functions")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := export.CopyProject(ce, out); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		".env",
+		"nhost/metadata/version.yaml",
+		"nhost/migrations/default/1_init/up.sql",
+	}
+	if diff := cmp.Diff(expected, listFiles(t, out)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(path), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDotenvRejectsUnsafePostgresPassword(t *testing.T) {
+	t.Parallel()
+
+	if _, err := export.Dotenv(
+		map[string]string{"POSTGRES_PASSWORD": "p@ss:word"}, nil, false,
+	); err == nil {
+		t.Errorf("expected an error for a password that breaks the database URLs")
+	}
+}
@@ -0,0 +1,15 @@
+package export
+
+import "github.com/urfave/cli/v2"
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "export",
+		Aliases: []string{},
+		Usage:   "Export the project to run it outside of the CLI",
+		Subcommands: []*cli.Command{
+			CommandCompose(),
+			CommandK8s(),
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/k8s"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagNamespace      = "namespace"
+	flagIngressClass   = "ingress-class"
+	flagTLSSecret      = "tls-secret"
+	flagFunctionsImage = "functions-image"
+	flagPostgresPass   = "postgres-password"
+	flagMinioUser      = "minio-user"
+	flagMinioPassword  = "minio-password"
+)
+
+func CommandK8s() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "k8s",
+		Aliases: []string{},
+		Usage:   "Export kubernetes manifests to run the project in a cluster",
+		Description: `Services are sized according to the resources in nhost.toml and exposed at
+<service>.<domain>. Ingresses rely on ingress-nginx annotations to rewrite
+paths. Migrations and metadata aren't included, apply them with the hasura
+CLI once the cluster is running.
+
+The credentials of postgres and minio are stored in their volumes, so they
+must not change once the cluster is running. They are kept from the file
+passed with --out if it exists, can be passed with --postgres-password,
+--minio-user and --minio-password and are only generated if not found.
+For instance:
+
+  nhost export k8s --namespace my-app --domain example.com --tls-secret example-com-tls | kubectl apply -f -`,
+		Action: commandK8s,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:     flagNamespace,
+				Usage:    "Namespace to create the objects in",
+				Required: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:     flagDomain,
+				Usage:    "Domain to expose the services at, for instance auth.<domain>",
+				Required: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagIngressClass,
+				Usage: "Ingress class of the ingresses",
+				Value: "nginx",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagTLSSecret,
+				Usage: "Secret with the TLS certificate for the services' hosts, if not set services are served over HTTP",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagFunctionsImage,
+				Usage: "Image with the project's functions, if not set functions aren't deployed",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:    flagPostgresPass,
+				Usage:   "Password of postgres, if not set it is kept from --out or generated",
+				EnvVars: []string{"NHOST_POSTGRES_PASSWORD"},
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:    flagMinioUser,
+				Usage:   "User of minio, if not set it is kept from --out or generated",
+				EnvVars: []string{"NHOST_MINIO_USER"},
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:    flagMinioPassword,
+				Usage:   "Password of minio, if not set it is kept from --out or generated",
+				EnvVars: []string{"NHOST_MINIO_PASSWORD"},
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:  flagPlaceholders,
+				Usage: "Write placeholders instead of the values in .secrets to the Secrets",
+				Value: false,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagOut,
+				Usage:     "Write the manifests to this file instead of stdout",
+				TakesFile: true,
+			},
+		},
+	}
+}
+
+func commandK8s(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	secrets, err := config.Secrets(ce)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if cCtx.Bool(flagPlaceholders) {
+		for _, s := range secrets {
+			s.Value = placeholder
+		}
+	}
+
+	cfg, err := config.ValidateWithSecrets(ce, "local", secrets)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	credentials, err := k8sCredentials(cCtx)
+	if err != nil {
+		return err
+	}
+
+	objects, err := k8s.Manifests(cfg, k8s.Options{
+		Namespace:        cCtx.String(flagNamespace),
+		Domain:           cCtx.String(flagDomain),
+		IngressClass:     cCtx.String(flagIngressClass),
+		TLSSecret:        cCtx.String(flagTLSSecret),
+		FunctionsImage:   cCtx.String(flagFunctionsImage),
+		PostgresPassword: credentials[0],
+		MinioUser:        credentials[1],
+		MinioPassword:    credentials[2],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate manifests: %w", err)
+	}
+
+	b, err := k8s.Render(objects)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if cCtx.String(flagOut) == "" {
+		ce.Println("%s", strings.TrimSuffix(string(b), "\n"))
+		return nil
+	}
+
+	if err := os.WriteFile(cCtx.String(flagOut), b, 0o600); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to write manifests: %w", err)
+	}
+
+	ce.Infoln("Manifests written to %s", cCtx.String(flagOut))
+
+	return nil
+}
+
+// k8sCredentials returns the postgres password and the minio user and
+// password. Values passed as flags take precedence over the ones in the file
+// passed with --out, values found in neither are generated.
+func k8sCredentials(cCtx *cli.Context) ([]string, error) {
+	credentials := []string{
+		cCtx.String(flagPostgresPass),
+		cCtx.String(flagMinioUser),
+		cCtx.String(flagMinioPassword),
+	}
+
+	if out := cCtx.String(flagOut); out != "" {
+		b, err := os.ReadFile(out)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("failed to read existing manifests: %w", err)
+		default:
+			postgresPassword, minioUser, minioPassword, err := k8s.Credentials(b)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			for i, v := range []string{postgresPassword, minioUser, minioPassword} {
+				if credentials[i] == "" {
+					credentials[i] = v
+				}
+			}
+		}
+	}
+
+	for i := range credentials {
+		if credentials[i] != "" {
+			continue
+		}
+		v, err := randomValue()
+		if err != nil {
+			return nil, err
+		}
+		credentials[i] = v
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,139 @@
+package graphql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/codegen"
+	"github.com/nhost/cli/gqlschema"
+	"github.com/urfave/cli/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	flagLang    = "lang"
+	flagPackage = "package"
+	flagSchema  = "schema"
+)
+
+func CommandCodegen() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "codegen",
+		ArgsUsage: "PATH...",
+		Aliases:   []string{},
+		Usage:     "Generate a typed client from the GraphQL operations in the .graphql files in PATH",
+		Description: `Operations are checked against the schema as seen by --role so generation fails
+if an operation uses a field the role can't access. The schema is introspected
+from the local development environment, a cloud project if --subdomain is
+specified, or read from --schema.`,
+		Action:       commandCodegen,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags: append(
+			endpointFlags(),
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagRole,
+				Usage: "Role to introspect the schema as, if not specified the admin schema is used",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:     flagLang,
+				Usage:    "Language to generate, either ts or go",
+				Required: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagOut,
+				Usage:     "File to write the generated code to",
+				Required:  true,
+				TakesFile: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagPackage,
+				Usage: "Package name for Go code, defaults to the name of the output directory",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagSchema,
+				Usage:     "Read the schema from this file (SDL or introspection JSON) instead of introspecting it",
+				TakesFile: true,
+			},
+		),
+	}
+}
+
+func goPackage(cCtx *cli.Context) (string, error) {
+	if cCtx.String(flagPackage) != "" {
+		return cCtx.String(flagPackage), nil
+	}
+
+	out, err := filepath.Abs(cCtx.String(flagOut))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	pkg := regexp.MustCompile(`[^a-z0-9_]`).ReplaceAllString(filepath.Base(filepath.Dir(out)), "")
+	if pkg == "" {
+		return "", fmt.Errorf("couldn't infer package name, use --%s", flagPackage) //nolint:goerr113
+	}
+	return pkg, nil
+}
+
+func codegenSchema(cCtx *cli.Context, ce *clienv.CliEnv) (*ast.Schema, error) {
+	if cCtx.String(flagSchema) != "" {
+		return gqlschema.Load(cCtx.String(flagSchema)) //nolint:wrapcheck
+	}
+
+	introspection, err := introspect(cCtx, ce, cCtx.String(flagRole))
+	if err != nil {
+		return nil, err
+	}
+	return introspection.AST(), nil
+}
+
+func commandCodegen(cCtx *cli.Context) error {
+	if cCtx.NArg() == 0 {
+		return fmt.Errorf("at least one path is required") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	lang := codegen.Lang(cCtx.String(flagLang))
+	if lang != codegen.LangTypeScript && lang != codegen.LangGo {
+		return fmt.Errorf("%w: %s", codegen.ErrUnsupportedLanguage, lang)
+	}
+
+	var pkg string
+	if lang == codegen.LangGo {
+		var err error
+		if pkg, err = goPackage(cCtx); err != nil {
+			return err
+		}
+	}
+
+	doc, err := codegen.LoadDocument(cCtx.Args().Slice())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	schema, err := codegenSchema(cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	b, err := codegen.Generate(schema, doc, lang, pkg)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cCtx.String(flagOut)), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(cCtx.String(flagOut), b, 0o644); err != nil { //nolint:gosec,gomnd
+		return fmt.Errorf("failed to write generated code: %w", err)
+	}
+
+	ce.Infoln("Generated %d operations in %s", len(doc.Operations), cCtx.String(flagOut))
+
+	return nil
+}
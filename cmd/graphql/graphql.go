@@ -0,0 +1,190 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/nhost/cli/hasura"
+	"github.com/nhost/cli/jwt"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagSubdomain  = "subdomain"
+	flagHTTPPort   = "http-port"
+	flagDisableTLS = "disable-tls"
+	flagRole       = "role"
+	flagUserID     = "user-id"
+	flagClaims     = "claims"
+	flagJWT        = "jwt"
+	flagPrivateKey = "private-key"
+)
+
+const defaultHTTPPort = 443
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "graphql",
+		Aliases: []string{},
+		Usage:   "Run GraphQL operations against the local development environment or a cloud project",
+		Subcommands: []*cli.Command{
+			CommandCodegen(),
+			CommandPermissions(),
+			CommandQuery(),
+			CommandSchema(),
+		},
+	}
+}
+
+func endpointFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:    flagSubdomain,
+			Usage:   "Project's subdomain to operate on, defaults to the local development environment",
+			EnvVars: []string{"NHOST_SUBDOMAIN"},
+		},
+		&cli.UintFlag{ //nolint:exhaustruct
+			Name:    flagHTTPPort,
+			Usage:   "HTTP port the local development environment listens on",
+			Value:   defaultHTTPPort,
+			EnvVars: []string{"NHOST_HTTP_PORT"},
+		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:    flagDisableTLS,
+			Usage:   "Local development environment was started with TLS disabled",
+			Value:   false,
+			EnvVars: []string{"NHOST_DISABLE_TLS"},
+		},
+	}
+}
+
+func sessionFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:  flagRole,
+			Usage: "Role to impersonate, if not specified requests are made as admin",
+		},
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:  flagUserID,
+			Usage: "User ID to impersonate",
+		},
+		&cli.StringSliceFlag{ //nolint:exhaustruct
+			Name:  flagClaims,
+			Usage: "Extra session variables in the form key=value, x-hasura- is prepended if missing",
+		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name:  flagJWT,
+			Usage: "Authenticate with a JWT minted with the project's JWT secret instead of the admin secret",
+		},
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name:      flagPrivateKey,
+			Usage:     "Path to the PEM private key to mint JWTs, required for RS* secrets",
+			TakesFile: true,
+		},
+	}
+}
+
+type target struct {
+	url string
+	cfg *model.ConfigConfig
+}
+
+// getTarget returns the GraphQL endpoint and the resolved configuration of the
+// local development environment or, if a subdomain is given, the cloud project.
+func getTarget(ctx context.Context, cCtx *cli.Context, ce *clienv.CliEnv) (*target, error) {
+	subdomain := cCtx.String(flagSubdomain)
+	if subdomain == "" || subdomain == "local" {
+		cfg, err := config.Validate(ce, "local")
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate config: %w", err)
+		}
+
+		return &target{
+			url: dockercompose.URL(
+				"graphql", cCtx.Uint(flagHTTPPort), !cCtx.Bool(flagDisableTLS),
+			) + "/v1",
+			cfg: cfg,
+		}, nil
+	}
+
+	proj, err := ce.GetAppInfo(ctx, subdomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app info: %w", err)
+	}
+
+	session, err := ce.LoadSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	cfg, err := config.Remote(ctx, ce, proj, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote config: %w", err)
+	}
+
+	return &target{
+		url: fmt.Sprintf(
+			"https://%s.graphql.%s.%s/v1", proj.Subdomain, proj.Region.AwsName, ce.Domain(),
+		),
+		cfg: cfg,
+	}, nil
+}
+
+type session struct {
+	role       string
+	userID     string
+	claims     map[string]string
+	useJWT     bool
+	privateKey []byte
+}
+
+func sessionFromCLI(cCtx *cli.Context) (session, error) {
+	claims, err := jwt.ParseClaims(cCtx.StringSlice(flagClaims))
+	if err != nil {
+		return session{}, err //nolint:wrapcheck
+	}
+
+	var privateKey []byte
+	if cCtx.String(flagPrivateKey) != "" {
+		privateKey, err = os.ReadFile(cCtx.String(flagPrivateKey))
+		if err != nil {
+			return session{}, fmt.Errorf("failed to read private key: %w", err)
+		}
+	}
+
+	return session{
+		role:       cCtx.String(flagRole),
+		userID:     cCtx.String(flagUserID),
+		claims:     claims,
+		useJWT:     cCtx.Bool(flagJWT),
+		privateKey: privateKey,
+	}, nil
+}
+
+// headers returns the headers to authenticate as the session, either
+// impersonating it with the admin secret or with a JWT.
+func (s session) headers(cfg *model.ConfigConfig) (http.Header, error) {
+	if s.useJWT {
+		token, err := jwt.Mint(cfg, s.role, s.userID, nil, s.claims, 0, s.privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mint token: %w", err)
+		}
+		return hasura.BearerHeaders(token), nil
+	}
+
+	vars := make(map[string]string, len(s.claims)+1)
+	for k, v := range s.claims {
+		vars[k] = v
+	}
+	if s.userID != "" {
+		vars["x-hasura-user-id"] = s.userID
+	}
+
+	return hasura.SessionHeaders(cfg.GetHasura().GetAdminSecret(), s.role, vars), nil
+}
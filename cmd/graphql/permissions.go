@@ -0,0 +1,172 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/permissions"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandPermissions() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "permissions",
+		Aliases: []string{},
+		Usage:   "Check hasura permissions",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "test",
+				ArgsUsage: "SPEC",
+				Aliases:   []string{},
+				Usage:     "Run the operations in SPEC as each role and compare the outcome with the expected one",
+				Description: `SPEC is a YAML file with the operations to run, the roles to run them as and
+the expected outcome for each of them. For instance:
+
+operations:
+  - name: list todos
+    query: |
+      query { todos { id title owner { email } } }
+    roles:
+      - role: user
+        session:
+          user-id: 00000000-0000-0000-0000-000000000001
+        expect:
+          rows:
+            todos: 2
+          nulls:
+            - todos.*.owner.email
+      - role: public
+        expect:
+          allowed: false
+
+Cases are identified by their name, which defaults to the role, and must be
+unique within an operation. Paths are relative to the response data, numeric
+elements index lists and * matches every element. The command exits with an error if any case doesn't match
+its expectations.`,
+				Action:       commandPermissionsTest,
+				BashComplete: clienv.CompleteSubdomain(),
+				Flags:        endpointFlags(),
+			},
+		},
+	}
+}
+
+// CaseResult is the outcome of a case as printed with --output json or yaml.
+type CaseResult struct {
+	Operation  string   `json:"operation"`
+	Case       string   `json:"case"`
+	Passed     bool     `json:"passed"`
+	Mismatches []string `json:"mismatches"`
+}
+
+func printResults(ce *clienv.CliEnv, results []permissions.Result) error {
+	data := make([]CaseResult, len(results))
+	for i, r := range results {
+		data[i] = CaseResult{
+			Operation:  r.Operation,
+			Case:       r.Case,
+			Passed:     r.Passed(),
+			Mismatches: append(make([]string, 0, len(r.Mismatches)), r.Mismatches...),
+		}
+	}
+
+	return ce.PrintData(data, func() { printMatrix(ce, results) }) //nolint:wrapcheck
+}
+
+func printMatrix(ce *clienv.CliEnv, results []permissions.Result) {
+	operations := make([]string, 0)
+	cases := make([]string, 0)
+	outcome := make(map[string]map[string]string)
+	for _, r := range results {
+		if _, ok := outcome[r.Operation]; !ok {
+			operations = append(operations, r.Operation)
+			outcome[r.Operation] = make(map[string]string)
+		}
+		if !contains(cases, r.Case) {
+			cases = append(cases, r.Case)
+		}
+
+		status := "PASS"
+		if !r.Passed() {
+			status = "FAIL"
+		}
+		outcome[r.Operation][r.Case] = status
+	}
+
+	columns := make([]clienv.Column, len(cases)+1)
+	columns[0] = clienv.Column{
+		Header: "Operation",
+		Rows:   operations,
+	}
+	for i, c := range cases {
+		rows := make([]string, len(operations))
+		for j, op := range operations {
+			rows[j] = outcome[op][c]
+			if rows[j] == "" {
+				rows[j] = "-"
+			}
+		}
+		columns[i+1] = clienv.Column{
+			Header: c,
+			Rows:   rows,
+		}
+	}
+
+	ce.Println("%s", clienv.Table(columns...))
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func commandPermissionsTest(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("invalid number of arguments") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	spec, err := permissions.LoadSpec(cCtx.Args().First())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	target, err := getTarget(cCtx.Context, cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	results, err := permissions.Run(
+		cCtx.Context, target.url, target.cfg.GetHasura().GetAdminSecret(), spec,
+	)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := printResults(ce, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		for _, m := range r.Mismatches {
+			ce.Warnln("%s as %s: %s", r.Operation, r.Case, m)
+		}
+		if !r.Passed() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases failed", failed, len(results)) //nolint:goerr113
+	}
+
+	ce.Infoln("All %d cases passed", len(results))
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/hasura"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagVariables     = "variables"
+	flagOperationName = "operation-name"
+)
+
+func CommandQuery() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:         "query",
+		ArgsUsage:    "FILE",
+		Aliases:      []string{},
+		Usage:        "Run a GraphQL operation read from FILE, use - to read from stdin",
+		Action:       commandQuery,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags: append(
+			append(endpointFlags(), sessionFlags()...),
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagVariables,
+				Usage:     "Path to a JSON file with the operation's variables",
+				TakesFile: true,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagOperationName,
+				Usage: "Operation to run if FILE contains more than one",
+			},
+		),
+	}
+}
+
+func readOperation(path string) (string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read operation: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func commandQuery(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("invalid number of arguments") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	query, err := readOperation(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	var variables map[string]any
+	if cCtx.String(flagVariables) != "" {
+		if err := clienv.UnmarshalFile(cCtx.String(flagVariables), &variables, json.Unmarshal); err != nil {
+			return fmt.Errorf("failed to parse variables: %w", err)
+		}
+	}
+
+	sess, err := sessionFromCLI(cCtx)
+	if err != nil {
+		return err
+	}
+
+	target, err := getTarget(cCtx.Context, cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	headers, err := sess.headers(target.cfg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hasura.NewClient(target.url, headers).Do(
+		cCtx.Context,
+		hasura.Request{
+			Query:         query,
+			Variables:     variables,
+			OperationName: cCtx.String(flagOperationName),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to run operation: %w", err)
+	}
+
+	b, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := ce.PrintData(resp, func() { ce.Println("%s", b) }); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(resp.Errors) > 0 {
+		return &hasura.ResponseError{Errors: resp.Errors}
+	}
+
+	return nil
+}
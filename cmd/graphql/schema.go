@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/gqlschema"
+	"github.com/nhost/cli/hasura"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagFormat = "format"
+	flagOut    = "out"
+)
+
+const (
+	formatSDL  = "sdl"
+	formatJSON = "json"
+)
+
+func CommandSchema() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:         "schema",
+		Aliases:      []string{},
+		Usage:        "Export the GraphQL schema as seen by a role",
+		Action:       commandSchema,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags: append(
+			endpointFlags(),
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagRole,
+				Usage: "Role to introspect the schema as, if not specified the admin schema is returned",
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagFormat,
+				Usage: "Output format, either sdl or json (introspection result)",
+				Value: formatSDL,
+			},
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:      flagOut,
+				Usage:     "Write the schema to this file instead of stdout",
+				TakesFile: true,
+			},
+		),
+	}
+}
+
+// introspect returns the schema of the target as seen by role.
+func introspect(cCtx *cli.Context, ce *clienv.CliEnv, role string) (*gqlschema.Introspection, error) {
+	target, err := getTarget(cCtx.Context, cCtx, ce)
+	if err != nil {
+		return nil, err
+	}
+
+	client := hasura.NewClient(
+		target.url,
+		hasura.SessionHeaders(target.cfg.GetHasura().GetAdminSecret(), role, nil),
+	)
+
+	return gqlschema.Introspect(cCtx.Context, client) //nolint:wrapcheck
+}
+
+func commandSchema(cCtx *cli.Context) error {
+	format := cCtx.String(flagFormat)
+	if format != formatSDL && format != formatJSON {
+		return fmt.Errorf("unsupported format: %s", format) //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	introspection, err := introspect(cCtx, ce, cCtx.String(flagRole))
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch format {
+	case formatJSON:
+		b, err = json.MarshalIndent(introspection, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal schema: %w", err)
+		}
+	default:
+		b = []byte(gqlschema.SDL(introspection.AST()))
+	}
+
+	// the SDL formatter already ends the schema with a newline
+	b = bytes.TrimRight(b, "\n")
+	if cCtx.String(flagOut) == "" {
+		ce.Println("%s", b)
+		return nil
+	}
+
+	b = append(b, '\n')
+
+	if err := os.WriteFile(cCtx.String(flagOut), b, 0o644); err != nil { //nolint:gosec,gomnd
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	return nil
+}
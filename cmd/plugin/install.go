@@ -0,0 +1,167 @@
+package plugin
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+const flagName = "name"
+
+func CommandInstall() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "install",
+		Aliases:   []string{},
+		Usage:     "Install a plugin from a URL",
+		ArgsUsage: "URL",
+		Description: `The URL can point to an executable, an archive or a git repository containing
+an nhost-<name> executable, for instance:
+
+    nhost plugin install https://example.com/releases/nhost-anonymise_linux_amd64.tar.gz
+    nhost plugin install --name anonymise https://example.com/releases/anonymise`,
+		Action: commandInstall,
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:  flagName,
+				Usage: "Name of the plugin, defaults to the name of the nhost-<name> executable",
+			},
+		},
+	}
+}
+
+// findExecutable returns the plugin executable in dir, the only nhost-*
+// executable or, if there is none, the only executable.
+func findExecutable(dir string) (string, error) {
+	var plugins, executables []string
+	if err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !isExecutable(p) {
+			return nil
+		}
+		executables = append(executables, p)
+		if strings.HasPrefix(d.Name(), prefix) {
+			plugins = append(plugins, p)
+		}
+		return nil
+	}); err != nil {
+		return "", fmt.Errorf("failed to read plugin: %w", err)
+	}
+
+	switch {
+	case len(plugins) == 1:
+		return plugins[0], nil
+	case len(plugins) == 0 && len(executables) == 1:
+		return executables[0], nil
+	default:
+		return "", fmt.Errorf( //nolint:goerr113
+			"expected a single nhost-<name> executable, found %d", len(plugins),
+		)
+	}
+}
+
+func pluginName(name, filename string) (string, error) {
+	if name == "" {
+		name = filename
+	}
+	name = strings.TrimPrefix(name, prefix)
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid plugin name %q, use --%s", name, flagName) //nolint:goerr113
+	}
+	return name, nil
+}
+
+// download fetches src into dst and returns the path to the plugin executable
+// and its file name.
+func download(cCtx *cli.Context, src, dst string) (string, string, error) {
+	client := &getter.Client{ //nolint:exhaustruct
+		Ctx:  cCtx.Context,
+		Src:  src,
+		Dst:  dst,
+		Mode: getter.ClientModeAny,
+	}
+	if err := client.Get(); err != nil {
+		return "", "", fmt.Errorf("failed to download plugin: %w", err)
+	}
+
+	// local files are symlinked instead of copied
+	dst, err := filepath.EvalSymlinks(dst)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download plugin: %w", err)
+	}
+	info, err := os.Stat(dst)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download plugin: %w", err)
+	}
+
+	if info.IsDir() {
+		executable, err := findExecutable(dst)
+		if err != nil {
+			return "", "", err
+		}
+		return executable, filepath.Base(executable), nil
+	}
+
+	filename := filepath.Base(dst)
+	if u, err := url.Parse(src); err == nil {
+		filename = path.Base(u.Path)
+	}
+	return dst, filename, nil
+}
+
+func commandInstall(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a URL is required") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	tmpdir, err := os.MkdirTemp("", "nhost-plugin-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary folder: %w", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	ce.Infoln("Downloading %s...", cCtx.Args().First())
+	executable, filename, err := download(
+		cCtx, cCtx.Args().First(), filepath.Join(tmpdir, "plugin"),
+	)
+	if err != nil {
+		return err
+	}
+
+	name, err := pluginName(cCtx.String(flagName), filename)
+	if err != nil {
+		return err
+	}
+	if cCtx.App.Command(name) != nil {
+		return fmt.Errorf("%s is a built-in command, use --%s", name, flagName) //nolint:goerr113
+	}
+
+	b, err := os.ReadFile(executable)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin: %w", err)
+	}
+	if err := os.MkdirAll(clienv.PathPluginsHome(), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create plugins folder: %w", err)
+	}
+	dst := filepath.Join(clienv.PathPluginsHome(), prefix+name)
+	if err := os.WriteFile(dst, b, 0o755); err != nil { //nolint:gomnd,gosec
+		return fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	ce.Infoln("Plugin installed in %s, run it with `nhost %s`", dst, name)
+	return nil
+}
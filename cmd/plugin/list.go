@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandList() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "list",
+		Aliases: []string{},
+		Usage:   "List available plugins",
+		Action:  commandList,
+	}
+}
+
+func commandList(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	plugins := Find(Folders(), cCtx.App.Commands)
+	if plugins == nil {
+		plugins = []Plugin{}
+	}
+
+	return ce.PrintData(plugins, func() { //nolint:wrapcheck
+		if len(plugins) == 0 {
+			ce.Infoln("No plugins found")
+			return
+		}
+
+		name := clienv.Column{Header: "Name", Rows: make([]string, 0, len(plugins))}
+		path := clienv.Column{Header: "Path", Rows: make([]string, 0, len(plugins))}
+		for _, p := range plugins {
+			name.Rows = append(name.Rows, p.Name)
+			path.Rows = append(path.Rows, p.Path)
+		}
+		ce.Println(clienv.Table(name, path))
+	})
+}
@@ -0,0 +1,198 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/run"
+	"github.com/urfave/cli/v2"
+)
+
+const prefix = "nhost-"
+
+// HelpTemplate is the help template of the CLI. It lists the plugins returned
+// by the app's ExtraInfo, see HelpExtraInfo, after the built-in commands.
+var HelpTemplate = strings.Replace( //nolint:gochecknoglobals
+	cli.AppHelpTemplate,
+	`COMMANDS:{{template "visibleCommandCategoryTemplate" .}}`,
+	`COMMANDS:{{template "visibleCommandCategoryTemplate" .}}{{with ExtraInfo}}
+
+   Plugins:{{range $name, $usage := .}}
+     {{$name}}{{"\t"}}{{$usage}}{{end}}{{end}}`,
+	1,
+)
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "plugin",
+		Aliases: []string{},
+		Usage:   "Manage plugins",
+		Description: `Plugins are executables named nhost-<name> found in the plugins folder or in
+the PATH, they are available as ` + "`nhost <name>`" + ` unless a built-in command has the same
+name. Plugins get the following environment variables:
+
+  NHOST_CLI                path to the nhost executable
+  NHOST_CLI_VERSION        version of the CLI
+  NHOST_DOMAIN             Nhost domain
+  NHOST_PROJECT_NAME       project name
+  NHOST_ROOT_FOLDER        root folder of the project
+  NHOST_NHOST_FOLDER       nhost folder of the project
+  NHOST_DOT_NHOST_FOLDER   .nhost folder of the project
+  NHOST_DATA_FOLDER        data folder of the local development environment
+
+Plugins that need to call the Nhost API can get an access token for the logged
+in user by running "$NHOST_CLI plugin token".`,
+		Subcommands: []*cli.Command{
+			CommandInstall(),
+			CommandList(),
+			CommandRemove(),
+			CommandToken(),
+		},
+	}
+}
+
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Folders returns the folders plugins are searched in, in order of
+// precedence.
+func Folders() []string {
+	return append(
+		[]string{clienv.PathPluginsHome()},
+		filepath.SplitList(os.Getenv("PATH"))...,
+	)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+// Find returns the plugins in folders. If several plugins have the same name
+// the one in the first folder is returned, plugins named like a command in
+// builtins are ignored.
+func Find(folders []string, builtins []*cli.Command) []Plugin {
+	seen := map[string]struct{}{"help": {}, "h": {}}
+	for _, cmd := range builtins {
+		for _, name := range cmd.Names() {
+			seen[name] = struct{}{}
+		}
+	}
+
+	var plugins []Plugin
+	for _, folder := range folders {
+		matches, _ := filepath.Glob(filepath.Join(folder, prefix+"*"))
+		for _, path := range matches {
+			name := strings.TrimPrefix(filepath.Base(path), prefix)
+			if _, ok := seen[name]; ok || name == "" || !isExecutable(path) {
+				continue
+			}
+			seen[name] = struct{}{}
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	return plugins
+}
+
+// Lookup returns the plugin called name in the first of folders that has it.
+func Lookup(folders []string, name string) (Plugin, bool) {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) {
+		return Plugin{}, false //nolint:exhaustruct
+	}
+
+	for _, folder := range folders {
+		path := filepath.Join(folder, prefix+name)
+		if isExecutable(path) {
+			return Plugin{Name: name, Path: path}, true
+		}
+	}
+	return Plugin{}, false //nolint:exhaustruct
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
+
+// Env returns the environment variables plugins get.
+func Env(ce *clienv.CliEnv, version string) map[string]string {
+	cli, err := os.Executable()
+	if err != nil {
+		cli = "nhost"
+	}
+
+	return map[string]string{
+		"NHOST_CLI":              cli,
+		"NHOST_CLI_VERSION":      version,
+		"NHOST_DOMAIN":           ce.Domain(),
+		"NHOST_PROJECT_NAME":     ce.ProjectName(),
+		"NHOST_ROOT_FOLDER":      absPath(ce.Path.Root()),
+		"NHOST_NHOST_FOLDER":     absPath(ce.Path.NhostFolder()),
+		"NHOST_DOT_NHOST_FOLDER": absPath(ce.Path.DotNhostFolder()),
+		"NHOST_DATA_FOLDER":      absPath(ce.Path.DataFolder()),
+	}
+}
+
+// HelpExtraInfo returns the ExtraInfo of app, the plugins listed by
+// HelpTemplate. Plugins are only searched for when the help is rendered.
+func HelpExtraInfo(app *cli.App) func() map[string]string {
+	return func() map[string]string {
+		info := map[string]string{}
+		for _, p := range Find(Folders(), app.Commands) {
+			info[p.Name] = "Plugin " + p.Path
+		}
+		return info
+	}
+}
+
+// CommandNotFound is the cli.CommandNotFoundFunc of the CLI. It runs the
+// plugin called name, plugins are only searched for when no built-in command
+// matches so the CLI doesn't scan the PATH on every start.
+func CommandNotFound(cCtx *cli.Context, name string) {
+	if err := commandNotFound(cCtx, name); err != nil {
+		clienv.ExitErrHandler(cCtx, err)
+	}
+}
+
+func commandNotFound(cCtx *cli.Context, name string) error {
+	ce := clienv.FromCLI(cCtx)
+
+	// plugins are only top level commands
+	if cCtx.Command != nil && cCtx.Command.Name != cCtx.App.Name {
+		return cli.Exit(fmt.Sprintf("No help topic for '%v'", name), clienv.ExitCodeCommandNotFound)
+	}
+
+	p, ok := Lookup(Folders(), name)
+	if !ok {
+		return cli.Exit(
+			fmt.Sprintf(
+				"unknown command %q, run `nhost help` to list the commands or `nhost plugin list` to list the plugins",
+				name,
+			),
+			clienv.ExitCodeCommandNotFound,
+		)
+	}
+
+	// nhost help <name>
+	if cCtx.Args().First() != name {
+		ce.Println("Plugin %s, run `nhost %s --help` for its usage", p.Path, p.Name)
+		return nil
+	}
+
+	code, err := run.Run(p.Path, cCtx.Args().Tail(), Env(ce, cCtx.App.Version))
+	if err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", p.Name, err)
+	}
+	if code != 0 {
+		return cli.Exit("", code)
+	}
+	return nil
+}
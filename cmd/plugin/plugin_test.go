@@ -0,0 +1,181 @@
+package plugin_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/plugin"
+	"github.com/urfave/cli/v2"
+)
+
+func writeFile(t *testing.T, path string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	first := t.TempDir()
+	second := t.TempDir()
+
+	writeFile(t, filepath.Join(first, "nhost-anonymise"), 0o755)
+	writeFile(t, filepath.Join(first, "nhost-notes.txt"), 0o644)
+	writeFile(t, filepath.Join(first, "other"), 0o755)
+	writeFile(t, filepath.Join(second, "nhost-anonymise"), 0o755)
+	writeFile(t, filepath.Join(second, "nhost-release-check"), 0o755)
+	writeFile(t, filepath.Join(second, "nhost-up"), 0o755)
+	writeFile(t, filepath.Join(second, "nhost-help"), 0o755)
+
+	builtins := []*cli.Command{
+		{Name: "up"}, //nolint:exhaustruct
+	}
+
+	got := plugin.Find([]string{first, second, filepath.Join(first, "missing")}, builtins)
+	expected := []plugin.Plugin{
+		{Name: "anonymise", Path: filepath.Join(first, "nhost-anonymise")},
+		{Name: "release-check", Path: filepath.Join(second, "nhost-release-check")},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	first := t.TempDir()
+	second := t.TempDir()
+
+	writeFile(t, filepath.Join(first, "nhost-notes"), 0o644)
+	writeFile(t, filepath.Join(second, "nhost-notes"), 0o755)
+	writeFile(t, filepath.Join(first, "nhost-anonymise"), 0o755)
+	writeFile(t, filepath.Join(second, "nhost-anonymise"), 0o755)
+
+	cases := []struct {
+		name     string
+		plugin   string
+		expected plugin.Plugin
+		found    bool
+	}{
+		{
+			name:     "first folder",
+			plugin:   "anonymise",
+			expected: plugin.Plugin{Name: "anonymise", Path: filepath.Join(first, "nhost-anonymise")},
+			found:    true,
+		},
+		{
+			name:     "skips non executables",
+			plugin:   "notes",
+			expected: plugin.Plugin{Name: "notes", Path: filepath.Join(second, "nhost-notes")},
+			found:    true,
+		},
+		{
+			name:     "missing",
+			plugin:   "release-check",
+			expected: plugin.Plugin{},
+			found:    false,
+		},
+		{
+			name:     "path",
+			plugin:   "../nhost-anonymise",
+			expected: plugin.Plugin{},
+			found:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := plugin.Lookup([]string{first, second}, tc.plugin)
+			if found != tc.found {
+				t.Errorf("expected found to be %t", tc.found)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCommandNotFound(t *testing.T) { //nolint:paralleltest
+	folder := t.TempDir()
+	out := filepath.Join(folder, "out")
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("PATH", folder)
+
+	if err := os.WriteFile(
+		filepath.Join(folder, "nhost-echo"),
+		[]byte("#!/bin/sh\necho \"$NHOST_CLI_VERSION $*\" > "+out+"\n"),
+		0o755, //nolint:gosec
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &cli.App{ //nolint:exhaustruct
+		Name:            "nhost",
+		Version:         "v1.2.3",
+		Flags:           flags,
+		Writer:          io.Discard,
+		ErrWriter:       io.Discard,
+		CommandNotFound: plugin.CommandNotFound,
+		Commands:        []*cli.Command{plugin.Command()},
+	}
+	if err := app.Run([]string{"nhost", "echo", "--flag", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("v1.2.3 --flag arg\n", string(b)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHelp(t *testing.T) { //nolint:paralleltest
+	folder := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("PATH", folder)
+
+	writeFile(t, filepath.Join(folder, "nhost-echo"), 0o755)
+	writeFile(t, filepath.Join(folder, "nhost-plugin"), 0o755)
+
+	var stdout bytes.Buffer
+	app := &cli.App{ //nolint:exhaustruct
+		Name:                  "nhost",
+		Writer:                &stdout,
+		CustomAppHelpTemplate: plugin.HelpTemplate,
+		Commands:              []*cli.Command{plugin.Command()},
+	}
+	app.ExtraInfo = plugin.HelpExtraInfo(app)
+	if err := app.Run([]string{"nhost", "help"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// nhost-plugin is shadowed by the built-in command
+	expected := "\n   Plugins:\n     echo  Plugin " + filepath.Join(folder, "nhost-echo") + "\n"
+	if !strings.Contains(stdout.String(), expected) {
+		t.Errorf("expected plugins to be listed in:\n%s", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "nhost-plugin") {
+		t.Errorf("expected nhost-plugin not to be listed in:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandRemove() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "remove",
+		Aliases:   []string{},
+		Usage:     "Remove a plugin installed with `nhost plugin install`",
+		ArgsUsage: "NAME",
+		Action:    commandRemove,
+	}
+}
+
+func commandRemove(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a plugin name is required") //nolint:goerr113
+	}
+	name := cCtx.Args().First()
+
+	ce := clienv.FromCLI(cCtx)
+
+	path := filepath.Join(clienv.PathPluginsHome(), prefix+name)
+	if !clienv.PathExists(path) {
+		if p, ok := Lookup(Folders(), name); ok {
+			return fmt.Errorf( //nolint:goerr113
+				"plugin %s wasn't installed with `nhost plugin install`, remove %s instead",
+				name, p.Path,
+			)
+		}
+		return fmt.Errorf("plugin %s not found", name) //nolint:goerr113
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove plugin: %w", err)
+	}
+
+	ce.Infoln("Plugin %s removed", name)
+	return nil
+}
@@ -0,0 +1,36 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandToken() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "token",
+		Aliases: []string{},
+		Usage:   "Print an access token for the logged in user, meant to be used by plugins",
+		Action:  commandToken,
+	}
+}
+
+func commandToken(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	// plugins capture the output so we can't ask for credentials
+	if !clienv.PathExists(ce.Path.AuthFile()) {
+		return clienv.NewError(
+			clienv.KindAuthFailed, fmt.Errorf("not logged in"), //nolint:goerr113
+		)
+	}
+
+	session, err := ce.LoadSession(cCtx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	ce.Println("%s", session.Session.AccessToken)
+	return nil
+}
@@ -8,13 +8,22 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+const flagSubdomain = "subdomain"
+
 func CommandLink() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:    "link",
-		Aliases: []string{},
-		Usage:   "Link local app to a remote one",
-		Action:  commandLink,
-		Flags:   []cli.Flag{},
+		Name:         "link",
+		Aliases:      []string{},
+		Usage:        "Link local app to a remote one",
+		Action:       commandLink,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:    flagSubdomain,
+				Usage:   "Link to the project with this subdomain instead of asking",
+				EnvVars: []string{"NHOST_SUBDOMAIN"},
+			},
+		},
 	}
 }
 
@@ -25,6 +34,6 @@ func commandLink(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to create .nhost folder: %w", err)
 	}
 
-	_, err := ce.Link(cCtx.Context)
+	_, err := ce.Link(cCtx.Context, cCtx.String(flagSubdomain))
 	return err //nolint:wrapcheck
 }
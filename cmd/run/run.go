@@ -0,0 +1,254 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/cmd/env"
+	"github.com/nhost/cli/dockercompose"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagSubdomain    = "subdomain"
+	flagHTTPPort     = "http-port"
+	flagDisableTLS   = "disable-tls"
+	flagPostgresPort = "postgres-port"
+)
+
+const (
+	defaultHTTPPort     = 443
+	defaultPostgresPort = 5432
+)
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "run",
+		ArgsUsage: "-- COMMAND [ARGS...]",
+		Aliases:   []string{},
+		Usage:     "Run a command with the project's environment variables and secrets",
+		Description: `The command gets the same environment variables as functions, including
+global environment variables, and the project's secrets. For instance:
+
+  nhost run -- npm test
+  nhost run --subdomain my-subdomain -- ./scripts/backfill.sh`,
+		Action:       commandRun,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags: []cli.Flag{
+			&cli.StringFlag{ //nolint:exhaustruct
+				Name:    flagSubdomain,
+				Usage:   "Project's subdomain to get the environment from, defaults to the local development environment",
+				EnvVars: []string{"NHOST_SUBDOMAIN"},
+			},
+			&cli.UintFlag{ //nolint:exhaustruct
+				Name:    flagHTTPPort,
+				Usage:   "HTTP port the local development environment listens on",
+				Value:   defaultHTTPPort,
+				EnvVars: []string{"NHOST_HTTP_PORT"},
+			},
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:    flagDisableTLS,
+				Usage:   "Local development environment was started with TLS disabled",
+				Value:   false,
+				EnvVars: []string{"NHOST_DISABLE_TLS"},
+			},
+			&cli.UintFlag{ //nolint:exhaustruct
+				Name:    flagPostgresPort,
+				Usage:   "Postgres port the local development environment listens on",
+				Value:   defaultPostgresPort,
+				EnvVars: []string{"NHOST_POSTGRES_PORT"},
+			},
+		},
+	}
+}
+
+func secretsEnv(secrets model.Secrets) map[string]string {
+	vars := make(map[string]string, len(secrets))
+	for _, s := range secrets {
+		vars[s.Name] = s.Value
+	}
+	return vars
+}
+
+// LocalEnv returns the environment of the functions in the local development
+// environment, replacing the addresses only reachable from within docker.
+func LocalEnv(ce *clienv.CliEnv, httpPort uint, useTLS bool, postgresPort uint) (map[string]string, error) {
+	cfg, err := config.Validate(ce, "local")
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	secrets, err := config.Secrets(ce)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	jwtSecret, err := dockercompose.JWTSecret(cfg)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	vars := secretsEnv(secrets)
+	for k, v := range dockercompose.FunctionsEnv(cfg, httpPort, useTLS, jwtSecret) {
+		vars[k] = v
+	}
+	vars["HASURA_GRAPHQL_DATABASE_URL"] = fmt.Sprintf(
+		"postgres://nhost_auth_admin@local.db.nhost.run:%d/local", postgresPort,
+	)
+	vars["HASURA_GRAPHQL_GRAPHQL_URL"] = dockercompose.URL("graphql", httpPort, useTLS) + "/v1"
+
+	return vars, nil
+}
+
+// remoteEnv returns the equivalent of the functions environment for a cloud
+// project.
+func remoteEnv(ctx context.Context, ce *clienv.CliEnv, subdomain string) (map[string]string, error) {
+	proj, err := ce.GetAppInfo(ctx, subdomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app info: %w", err)
+	}
+
+	session, err := ce.LoadSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	cfg, err := config.Remote(ctx, ce, proj, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote config: %w", err)
+	}
+
+	secrets, err := config.RemoteSecrets(ctx, ce, proj, session)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	jwtSecret, err := dockercompose.JWTSecret(cfg)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	hasuraURL := fmt.Sprintf(
+		"https://%s.hasura.%s.%s", proj.Subdomain, proj.Region.AwsName, ce.Domain(),
+	)
+
+	vars := secretsEnv(secrets)
+	for k, v := range env.CloudVariables(proj.Subdomain, proj.Region.AwsName, ce.Domain()) {
+		vars[k] = v
+	}
+	vars["HASURA_GRAPHQL_ADMIN_SECRET"] = cfg.GetHasura().GetAdminSecret()
+	vars["HASURA_GRAPHQL_GRAPHQL_URL"] = hasuraURL + "/v1/graphql"
+	vars["HASURA_GRAPHQL_JWT_SECRET"] = jwtSecret
+	vars["NHOST_ADMIN_SECRET"] = cfg.GetHasura().GetAdminSecret()
+	vars["NHOST_HASURA_URL"] = hasuraURL + "/console"
+	vars["NHOST_JWT_SECRET"] = jwtSecret
+	vars["NHOST_WEBHOOK_SECRET"] = cfg.GetHasura().GetWebhookSecret()
+	for _, v := range cfg.GetGlobal().GetEnvironment() {
+		vars[v.GetName()] = v.GetValue()
+	}
+
+	return vars, nil
+}
+
+// Environ returns the current environment with vars added, vars take
+// precedence over existing variables.
+func Environ(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	environ := os.Environ()
+	for _, k := range keys {
+		environ = append(environ, k+"="+vars[k])
+	}
+	return environ
+}
+
+// Run executes the command with vars added to its environment forwarding any
+// signal received and returns its exit code.
+func Run(name string, args []string, vars map[string]string) (int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = Environ(vars)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start command: %w", err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				_ = cmd.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+				return 128 + int(status.Signal()), nil //nolint:gomnd
+			}
+			return exitErr.ExitCode(), nil
+		}
+		return 0, fmt.Errorf("failed to run command: %w", err)
+	}
+
+	return 0, nil
+}
+
+func commandRun(cCtx *cli.Context) error {
+	if cCtx.NArg() == 0 {
+		return fmt.Errorf("a command to run is required") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	var (
+		vars map[string]string
+		err  error
+	)
+	if subdomain := cCtx.String(flagSubdomain); subdomain != "" && subdomain != "local" {
+		vars, err = remoteEnv(cCtx.Context, ce, subdomain)
+	} else {
+		vars, err = LocalEnv(
+			ce, cCtx.Uint(flagHTTPPort), !cCtx.Bool(flagDisableTLS), cCtx.Uint(flagPostgresPort),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := Run(cCtx.Args().First(), cCtx.Args().Tail(), vars)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return cli.Exit("", code)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package run_test
+
+import (
+	"testing"
+
+	"github.com/nhost/cli/cmd/run"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		script   string
+		expected int
+	}{
+		{
+			name:     "success",
+			script:   `test "$NHOST_SUBDOMAIN" = local`,
+			expected: 0,
+		},
+		{
+			name:     "exit code",
+			script:   "exit 3",
+			expected: 3,
+		},
+		{
+			name:     "killed by signal",
+			script:   "kill -TERM $$",
+			expected: 143,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := run.Run(
+				"sh", []string{"-c", tc.script}, map[string]string{"NHOST_SUBDOMAIN": "local"},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected exit code %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -10,12 +10,13 @@ import (
 
 func CommandCreate() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:      "create",
-		ArgsUsage: "NAME VALUE",
-		Aliases:   []string{},
-		Usage:     "Create secret in the cloud environment",
-		Action:    commandCreate,
-		Flags:     commonFlags(),
+		Name:         "create",
+		ArgsUsage:    "NAME VALUE",
+		Aliases:      []string{},
+		Usage:        "Create secret in the cloud environment",
+		Action:       commandCreate,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags:        commonFlags(),
 	}
 }
 
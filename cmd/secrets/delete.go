@@ -10,12 +10,13 @@ import (
 
 func CommandDelete() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:      "delete",
-		ArgsUsage: "NAME",
-		Aliases:   []string{},
-		Usage:     "Delete secret in the cloud environment",
-		Action:    commandDelete,
-		Flags:     commonFlags(),
+		Name:         "delete",
+		ArgsUsage:    "NAME",
+		Aliases:      []string{},
+		Usage:        "Delete secret in the cloud environment",
+		Action:       commandDelete,
+		Flags:        commonFlags(),
+		BashComplete: completeSecrets(),
 	}
 }
 
@@ -8,13 +8,18 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+type Secret struct {
+	Name string `json:"name"`
+}
+
 func CommandList() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:    "list",
-		Aliases: []string{},
-		Usage:   "List secrets in the cloud environment",
-		Action:  commandList,
-		Flags:   commonFlags(),
+		Name:         "list",
+		Aliases:      []string{},
+		Usage:        "List secrets in the cloud environment",
+		Action:       commandList,
+		BashComplete: clienv.CompleteSubdomain(),
+		Flags:        commonFlags(),
 	}
 }
 
@@ -41,9 +46,14 @@ func commandList(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to get secrets: %w", err)
 	}
 
-	for _, secret := range secrets.GetAppSecrets() {
-		ce.Println(secret.Name)
+	list := make([]Secret, len(secrets.GetAppSecrets()))
+	for i, secret := range secrets.GetAppSecrets() {
+		list[i] = Secret{Name: secret.Name}
 	}
 
-	return nil
+	return ce.PrintData(list, func() { //nolint:wrapcheck
+		for _, secret := range list {
+			ce.Println(secret.Name)
+		}
+	})
 }
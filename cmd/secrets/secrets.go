@@ -1,8 +1,18 @@
 package secrets
 
-import "github.com/urfave/cli/v2"
+import (
+	"context"
+	"time"
 
-const flagSubdomain = "subdomain"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/nhostclient/graphql"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagSubdomain     = "subdomain"
+	completionTimeout = 5 * time.Second
+)
 
 func commonFlags() []cli.Flag {
 	return []cli.Flag{
@@ -27,3 +37,47 @@ func Command() *cli.Command {
 		},
 	}
 }
+
+// secretNames suggests the names of the secrets of the project when completing
+// the first argument of a command.
+func secretNames(cCtx *cli.Context) []string {
+	if cCtx.NArg() > 0 {
+		return nil
+	}
+
+	ce := clienv.FromCLI(cCtx)
+	ctx, cancel := context.WithTimeout(cCtx.Context, completionTimeout)
+	defer cancel()
+
+	proj, err := ce.CompletionApp(ctx, cCtx.String(flagSubdomain))
+	if err != nil {
+		return nil
+	}
+
+	session, err := ce.CompletionSession(ctx)
+	if err != nil {
+		return nil
+	}
+
+	secrets, err := ce.GetNhostClient().GetSecrets(
+		ctx,
+		proj.ID,
+		graphql.WithAccessToken(session.Session.AccessToken),
+	)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(secrets.GetAppSecrets()))
+	for _, secret := range secrets.GetAppSecrets() {
+		names = append(names, secret.Name)
+	}
+	return names
+}
+
+func completeSecrets() cli.BashCompleteFunc {
+	return clienv.Complete(
+		map[string]clienv.Completer{flagSubdomain: clienv.Subdomains},
+		secretNames,
+	)
+}
@@ -10,12 +10,13 @@ import (
 
 func CommandUpdate() *cli.Command {
 	return &cli.Command{ //nolint:exhaustruct
-		Name:      "update",
-		ArgsUsage: "NAME VALUE",
-		Aliases:   []string{},
-		Usage:     "Update secret in the cloud environment",
-		Action:    commandUpdate,
-		Flags:     commonFlags(),
+		Name:         "update",
+		ArgsUsage:    "NAME VALUE",
+		Aliases:      []string{},
+		Usage:        "Update secret in the cloud environment",
+		Action:       commandUpdate,
+		Flags:        commonFlags(),
+		BashComplete: completeSecrets(),
 	}
 }
 
@@ -0,0 +1,42 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandGet() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "get",
+		Aliases:   []string{},
+		Usage:     "Print the effective value of a setting",
+		ArgsUsage: "KEY",
+		Action:    commandGet,
+	}
+}
+
+func commandGet(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a key is required") //nolint:goerr113
+	}
+	key := cCtx.Args().First()
+
+	ce := clienv.FromCLI(cCtx)
+
+	def, err := lookupFlag(key)
+	if err != nil {
+		return err
+	}
+	user, project, err := loadSettings(ce)
+	if err != nil {
+		return err
+	}
+
+	setting := resolve(cCtx, os.Args[1:], key, def, user, project)
+	return ce.PrintData(setting, func() { //nolint:wrapcheck
+		ce.Println("%s", setting.Value)
+	})
+}
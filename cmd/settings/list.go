@@ -0,0 +1,36 @@
+package settings
+
+import (
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandList() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "list",
+		Aliases: []string{},
+		Usage:   "List settings with their effective values and where they come from",
+		Action:  commandList,
+	}
+}
+
+func commandList(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	settings, err := All(cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	return ce.PrintData(settings, func() { //nolint:wrapcheck
+		key := clienv.Column{Header: "Key", Rows: make([]string, 0, len(settings))}
+		value := clienv.Column{Header: "Value", Rows: make([]string, 0, len(settings))}
+		source := clienv.Column{Header: "Source", Rows: make([]string, 0, len(settings))}
+		for _, s := range settings {
+			key.Rows = append(key.Rows, s.Key)
+			value.Rows = append(value.Rows, s.Value)
+			source.Rows = append(source.Rows, s.Source)
+		}
+		ce.Println(clienv.Table(key, value, source))
+	})
+}
@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+const flagUser = "user"
+
+func CommandSet() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "set",
+		Aliases:   []string{},
+		Usage:     "Set a setting in the project's settings file",
+		ArgsUsage: "KEY VALUE",
+		Action:    commandSet,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{ //nolint:exhaustruct
+				Name:  flagUser,
+				Usage: "Set it in the user's settings file instead",
+				Value: false,
+			},
+		},
+	}
+}
+
+// parseValue validates value as the flag would and returns it with the type
+// it should have in the settings file.
+func parseValue(f cli.Flag, value string) (any, error) {
+	fs := flag.NewFlagSet("settings", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := f.Apply(fs); err != nil {
+		return nil, fmt.Errorf("failed to validate value: %w", err)
+	}
+
+	name := f.Names()[0]
+	switch f.(type) {
+	case *cli.StringSliceFlag:
+		values := strings.Split(value, ",")
+		list := make([]any, 0, len(values))
+		for _, v := range values {
+			list = append(list, strings.TrimSpace(v))
+		}
+		return list, nil
+	case *cli.BoolFlag:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+		return b, nil
+	case *cli.UintFlag, *cli.IntFlag, *cli.Uint64Flag, *cli.Int64Flag:
+		if err := fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+		n, _ := strconv.ParseInt(value, 10, 64)
+		return n, nil
+	default:
+		if err := fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+		return value, nil
+	}
+}
+
+func commandSet(cCtx *cli.Context) error {
+	if cCtx.NArg() != 2 { //nolint:gomnd
+		return fmt.Errorf("a key and a value are required") //nolint:goerr113
+	}
+	key, value := cCtx.Args().Get(0), cCtx.Args().Get(1)
+
+	ce := clienv.FromCLI(cCtx)
+
+	def, err := lookupFlag(key)
+	if err != nil {
+		return err
+	}
+	v, err := parseValue(def.flag, value)
+	if err != nil {
+		return err
+	}
+
+	file := clienv.ProjectSettingsFile(ce.Path.NhostFolder())
+	if cCtx.Bool(flagUser) {
+		file = clienv.UserSettingsFile()
+	}
+
+	settings, err := clienv.LoadSettings(file)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	settings.Set(def.section, def.flag.Names()[0], v)
+	if err := settings.Save(file); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	ce.Infoln("%s set to %s in %s", key, value, file)
+	return nil
+}
@@ -0,0 +1,190 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/dev"
+	"github.com/urfave/cli/v2"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "settings",
+		Aliases: []string{},
+		Usage:   "Manage defaults for flags",
+		Description: `Defaults for global flags and the flags of ` + "`nhost up`" + ` can be stored in
+nhost/settings.toml, to share them with everyone working on the project, or in
+the user's settings file, which takes precedence. Flags and environment
+variables take precedence over both. For instance:
+
+    nhost settings set up.http-port 8443
+    nhost settings set --user up.disable-tls true`,
+		Subcommands: []*cli.Command{
+			CommandGet(),
+			CommandList(),
+			CommandSet(),
+		},
+	}
+}
+
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type flagDef struct {
+	section string
+	flag    cli.Flag
+}
+
+// catalog returns the flags that can be set in settings files indexed by
+// key.
+func catalog() (map[string]flagDef, error) {
+	global, err := clienv.Flags()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	sections := map[string][]cli.Flag{
+		"":   global,
+		"up": dev.CommandUp().Flags,
+	}
+
+	defs := map[string]flagDef{}
+	for section, flags := range sections {
+		for _, f := range flags {
+			defs[clienv.SettingKey(section, f.Names()[0])] = flagDef{section: section, flag: f}
+		}
+	}
+	return defs, nil
+}
+
+func lookupFlag(key string) (flagDef, error) {
+	defs, err := catalog()
+	if err != nil {
+		return flagDef{}, err
+	}
+	def, ok := defs[key]
+	if !ok {
+		return flagDef{}, fmt.Errorf( //nolint:goerr113
+			"unknown setting %s, run `nhost settings list` to see the available settings", key,
+		)
+	}
+	return def, nil
+}
+
+func defaultValue(f cli.Flag) string {
+	if b, ok := f.(*cli.BoolFlag); ok {
+		return strconv.FormatBool(b.Value)
+	}
+	if df, ok := f.(cli.DocGenerationFlag); ok {
+		return df.GetValue()
+	}
+	return ""
+}
+
+func envValue(f cli.Flag) (string, bool) {
+	df, ok := f.(cli.DocGenerationFlag)
+	if !ok {
+		return "", false
+	}
+	for _, env := range df.GetEnvVars() {
+		if v, ok := os.LookupEnv(env); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func globalValue(cCtx *cli.Context, f cli.Flag) string {
+	name := f.Names()[0]
+	if _, ok := f.(*cli.StringSliceFlag); ok {
+		return strings.Join(cCtx.StringSlice(name), ",")
+	}
+	return fmt.Sprint(cCtx.Value(name))
+}
+
+// resolve returns the effective value of a setting and where it comes from.
+// Global flags are resolved by the CLI so we only need to find out their
+// source, in order of precedence: args, environment variables and settings
+// files. The flags of commands are resolved the way the command would.
+func resolve(
+	cCtx *cli.Context,
+	args []string,
+	key string,
+	def flagDef,
+	user, project clienv.Settings,
+) Setting {
+	name := def.flag.Names()[0]
+
+	if def.section == "" {
+		source := clienv.SourceDefault
+		_, fromEnv := envValue(def.flag)
+		switch {
+		case clienv.FlagPassed(args, def.flag):
+			source = clienv.SourceFlag
+		case fromEnv:
+			source = clienv.SourceEnv
+		case clienv.SettingSources(cCtx)[key] != "":
+			source = clienv.SettingSources(cCtx)[key]
+		}
+		return Setting{Key: key, Value: globalValue(cCtx, def.flag), Source: source}
+	}
+
+	if v, ok := envValue(def.flag); ok {
+		return Setting{Key: key, Value: v, Source: clienv.SourceEnv}
+	}
+	if v, ok := user.Lookup(def.section, name); ok {
+		return Setting{
+			Key: key, Value: strings.Join(clienv.FormatSetting(v), ","), Source: clienv.SourceUser,
+		}
+	}
+	if v, ok := project.Lookup(def.section, name); ok {
+		return Setting{
+			Key: key, Value: strings.Join(clienv.FormatSetting(v), ","), Source: clienv.SourceProject,
+		}
+	}
+	return Setting{Key: key, Value: defaultValue(def.flag), Source: clienv.SourceDefault}
+}
+
+func loadSettings(ce *clienv.CliEnv) (clienv.Settings, clienv.Settings, error) {
+	user, err := clienv.LoadSettings(clienv.UserSettingsFile())
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+	project, err := clienv.LoadSettings(clienv.ProjectSettingsFile(ce.Path.NhostFolder()))
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+	return user, project, nil
+}
+
+// All returns the effective value of every setting sorted by key.
+func All(cCtx *cli.Context, ce *clienv.CliEnv) ([]Setting, error) {
+	defs, err := catalog()
+	if err != nil {
+		return nil, err
+	}
+	user, project, err := loadSettings(ce)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(defs))
+	for k := range defs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for _, k := range keys {
+		settings = append(settings, resolve(cCtx, os.Args[1:], k, defs[k], user, project))
+	}
+	return settings, nil
+}
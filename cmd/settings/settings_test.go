@@ -0,0 +1,188 @@
+package settings_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/dev"
+	"github.com/nhost/cli/cmd/settings"
+	"github.com/urfave/cli/v2"
+)
+
+type upFlags struct {
+	HTTPPort     uint
+	PostgresPort uint
+	DisableTLS   bool
+	Without      []string
+	ProjectName  string
+}
+
+func writeSettings(t *testing.T, file, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runUp(t *testing.T, args ...string) upFlags {
+	t.Helper()
+
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got upFlags
+	up := dev.CommandUp()
+	up.Action = func(cCtx *cli.Context) error {
+		got = upFlags{
+			HTTPPort:     cCtx.Uint("http-port"),
+			PostgresPort: cCtx.Uint("postgres-port"),
+			DisableTLS:   cCtx.Bool("disable-tls"),
+			Without:      cCtx.StringSlice("without"),
+			ProjectName:  cCtx.String("project-name"),
+		}
+		return nil
+	}
+
+	app := &cli.App{ //nolint:exhaustruct
+		Name:     "nhost",
+		Flags:    flags,
+		Before:   clienv.ApplySettings(""),
+		Commands: []*cli.Command{up},
+	}
+	if err := app.Run(append([]string{"nhost"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestApplySettings(t *testing.T) { //nolint:paralleltest
+	nhostFolder := t.TempDir()
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	t.Setenv("NHOST_POSTGRES_PORT", "4000")
+
+	writeSettings(t, clienv.ProjectSettingsFile(nhostFolder), `
+project-name = "fromproject"
+
+[up]
+http-port = 1000
+postgres-port = 2000
+disable-tls = true
+without = ["dashboard", "mailhog"]
+`)
+	writeSettings(t, clienv.UserSettingsFile(), `
+[up]
+http-port = 3000
+`)
+
+	cases := []struct {
+		name     string
+		args     []string
+		expected upFlags
+	}{
+		{
+			name: "settings files",
+			args: []string{"--nhost-folder", nhostFolder, "up"},
+			expected: upFlags{
+				HTTPPort:     3000,
+				PostgresPort: 4000,
+				DisableTLS:   true,
+				Without:      []string{"dashboard", "mailhog"},
+				ProjectName:  "fromproject",
+			},
+		},
+		{
+			name: "flags take precedence",
+			args: []string{
+				"--nhost-folder", nhostFolder, "--project-name", "fromflag",
+				"up", "--http-port", "5000", "--postgres-port", "6000", "--without", "auth",
+			},
+			expected: upFlags{
+				HTTPPort:     5000,
+				PostgresPort: 6000,
+				DisableTLS:   true,
+				Without:      []string{"auth"},
+				ProjectName:  "fromflag",
+			},
+		},
+	}
+
+	for _, tc := range cases { //nolint:paralleltest
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := runUp(t, tc.args...)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestListSources(t *testing.T) { //nolint:paralleltest
+	nhostFolder := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("NHOST_PROJECT_NAME", "fromenv")
+	t.Setenv("NHOST_DOMAIN", "staging.nhost.run")
+
+	writeSettings(t, clienv.ProjectSettingsFile(nhostFolder), `
+yes = true
+`)
+
+	flags, err := clienv.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	app := &cli.App{ //nolint:exhaustruct
+		Name:     "nhost",
+		Flags:    flags,
+		Writer:   &stdout,
+		Before:   clienv.ApplySettings(""),
+		Commands: []*cli.Command{settings.Command()},
+	}
+
+	// sources are found out from the process' arguments
+	args := []string{
+		"nhost", "--nhost-folder", nhostFolder, "--project-name", "fromflag",
+		"--output", "json", "settings", "list",
+	}
+	osArgs := os.Args
+	os.Args = args
+	t.Cleanup(func() { os.Args = osArgs })
+
+	if err := app.Run(args); err != nil {
+		t.Fatal(err)
+	}
+
+	var list []settings.Setting
+	if err := json.Unmarshal(stdout.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]settings.Setting{}
+	for _, s := range list {
+		got[s.Key] = s
+	}
+
+	expected := map[string]settings.Setting{
+		"project-name": {Key: "project-name", Value: "fromflag", Source: clienv.SourceFlag},
+		"domain":       {Key: "domain", Value: "staging.nhost.run", Source: clienv.SourceEnv},
+		"yes":          {Key: "yes", Value: "true", Source: clienv.SourceProject},
+		"debug":        {Key: "debug", Value: "false", Source: clienv.SourceDefault},
+	}
+	for key, e := range expected {
+		if diff := cmp.Diff(e, got[key]); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -45,13 +45,12 @@ func commandUninstall(cCtx *cli.Context) error {
 	ce.Infoln("Found Nhost cli in %s", path)
 
 	if !cCtx.Bool(forceFlag) {
-		ce.PromptMessage("Are you sure you want to uninstall Nhost CLI? [y/N] ")
-		resp, err := ce.PromptInput(false)
+		ok, err := ce.Confirm(forceFlag, "Are you sure you want to uninstall Nhost CLI?")
 		if err != nil {
 			return fmt.Errorf("failed to read user input: %w", err)
 		}
 
-		if resp != "y" && resp != "Y" {
+		if !ok {
 			return nil
 		}
 	}
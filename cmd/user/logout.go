@@ -0,0 +1,20 @@
+package user
+
+import (
+	"github.com/nhost/cli/clienv"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandLogout() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "logout",
+		Aliases: []string{},
+		Usage:   "Revoke the stored personal access token and remove it",
+		Action:  commandLogout,
+	}
+}
+
+func commandLogout(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+	return ce.Logout(cCtx.Context) //nolint:wrapcheck
+}
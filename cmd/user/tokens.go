@@ -0,0 +1,208 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/nhostclient/graphql"
+	"github.com/urfave/cli/v2"
+)
+
+const tokenIDLength = 8
+
+func CommandTokens() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "tokens",
+		Aliases: []string{},
+		Usage:   "Manage the personal access tokens created by the CLI",
+		Description: `Every login creates a personal access token that is valid for 90 days, the
+CLI replaces the one it is using automatically when it's about to expire.`,
+		Subcommands: []*cli.Command{
+			CommandTokensList(),
+			CommandTokensRevoke(),
+		},
+	}
+}
+
+func CommandTokensList() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "list",
+		Aliases: []string{},
+		Usage:   "List the personal access tokens created by the CLI",
+		Action:  commandTokensList,
+	}
+}
+
+func CommandTokensRevoke() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:      "revoke",
+		Aliases:   []string{},
+		Usage:     "Revoke a personal access token created by the CLI",
+		ArgsUsage: "ID",
+		Action:    commandTokensRevoke,
+	}
+}
+
+type Token struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Current   bool      `json:"current"`
+
+	hash string
+}
+
+// normalizeHash returns hash as plain hex. Depending on its version auth
+// returns the hex encoded bytea, prefixed with \x, or plain hex.
+func normalizeHash(hash string) string {
+	return strings.TrimPrefix(hash, `\x`)
+}
+
+// Tokens returns the PATs identified by the beginning of their hash so they
+// can be told apart without revealing any part of them. current is the PAT
+// stored by the CLI, it is only compared to the hashes.
+func Tokens(
+	pats []*graphql.GetPersonalAccessTokens_AuthRefreshTokens,
+	current string,
+) []Token {
+	sum := sha256.Sum256([]byte(current))
+	currentHash := hex.EncodeToString(sum[:])
+
+	tokens := make([]Token, 0, len(pats))
+	for _, pat := range pats {
+		hash := ""
+		if pat.GetRefreshTokenHash() != nil {
+			hash = *pat.GetRefreshTokenHash()
+		}
+
+		id := normalizeHash(hash)
+		full := id
+		if len(id) > tokenIDLength {
+			id = id[:tokenIDLength]
+		}
+		tokens = append(tokens, Token{
+			ID:        id,
+			CreatedAt: pat.CreatedAt,
+			ExpiresAt: pat.ExpiresAt,
+			Current:   full != "" && full == currentHash,
+			hash:      hash,
+		})
+	}
+	return tokens
+}
+
+// FindToken returns the token whose ID starts with id.
+func FindToken(tokens []Token, id string) (Token, error) {
+	var found []Token
+	for _, t := range tokens {
+		if id != "" && strings.HasPrefix(t.ID, id) {
+			found = append(found, t)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Token{}, fmt.Errorf( //nolint:goerr113
+			"token %s not found, run `nhost user tokens list` to see your tokens", id,
+		)
+	case 1:
+		return found[0], nil
+	default:
+		return Token{}, fmt.Errorf("token ID %s is ambiguous", id) //nolint:goerr113
+	}
+}
+
+func listTokens(cCtx *cli.Context, ce *clienv.CliEnv) ([]Token, string, error) {
+	creds, err := loadCredentials(ce)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session, err := ce.LoadSession(cCtx.Context)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load session: %w", err)
+	}
+
+	pats, err := ce.GetNhostClient().GetPATs(
+		cCtx.Context, session.Session.User.ID, session.Session.AccessToken,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get tokens: %w", err)
+	}
+
+	// LoadSession might have rotated the PAT
+	if creds, err = loadCredentials(ce); err != nil {
+		return nil, "", err
+	}
+
+	return Tokens(pats, creds.PersonalAccessToken), session.Session.AccessToken, nil
+}
+
+func commandTokensList(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	tokens, _, err := listTokens(cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	return ce.PrintData(tokens, func() { //nolint:wrapcheck
+		current := clienv.Column{Header: "", Rows: make([]string, 0, len(tokens))}
+		id := clienv.Column{Header: "ID", Rows: make([]string, 0, len(tokens))}
+		created := clienv.Column{Header: "Created", Rows: make([]string, 0, len(tokens))}
+		expires := clienv.Column{Header: "Expires", Rows: make([]string, 0, len(tokens))}
+		for _, t := range tokens {
+			mark := ""
+			if t.Current {
+				mark = "*"
+			}
+			current.Rows = append(current.Rows, mark)
+			id.Rows = append(id.Rows, t.ID)
+			created.Rows = append(created.Rows, t.CreatedAt.Format(time.DateOnly))
+			expires.Rows = append(expires.Rows, t.ExpiresAt.Format(time.DateOnly))
+		}
+		ce.Println(clienv.Table(current, id, created, expires))
+	})
+}
+
+func commandTokensRevoke(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("a token ID is required") //nolint:goerr113
+	}
+
+	ce := clienv.FromCLI(cCtx)
+
+	tokens, accessToken, err := listTokens(cCtx, ce)
+	if err != nil {
+		return err
+	}
+
+	token, err := FindToken(tokens, cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+	if token.Current {
+		return fmt.Errorf( //nolint:goerr113
+			"token %s is the one used by the CLI, run `nhost user logout` to revoke it", token.ID,
+		)
+	}
+
+	ok, err := ce.Confirm("", "Revoke token %s?", token.ID)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !ok {
+		return nil
+	}
+
+	if err := ce.GetNhostClient().DeletePATByHash(cCtx.Context, token.hash, accessToken); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	ce.Infoln("Token %s revoked", token.ID)
+	return nil
+}
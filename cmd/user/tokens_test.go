@@ -0,0 +1,112 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/nhost/cli/cmd/user"
+	"github.com/nhost/cli/nhostclient/graphql"
+)
+
+func TestFindToken(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	expires := created.Add(90 * 24 * time.Hour)
+
+	hash := func(s string) *string { return &s }
+
+	tokens := user.Tokens(
+		[]*graphql.GetPersonalAccessTokens_AuthRefreshTokens{
+			{
+				RefreshTokenHash: hash("0c5b1f2e6d9a4b7e9f3a1e2d3c4b5a690c5b1f2e6d9a4b7e9f3a1e2d3c4b5a69"),
+				CreatedAt:        created,
+				ExpiresAt:        expires,
+			},
+			{
+				RefreshTokenHash: hash("0c5b77aa1b2c4d3e8f9a0b1c2d3e4f500c5b77aa1b2c4d3e8f9a0b1c2d3e4f50"),
+				CreatedAt:        created,
+				ExpiresAt:        expires,
+			},
+			{
+				// hex encoded bytea of the sha256 of the current token
+				RefreshTokenHash: hash(`\x53cc8fd729d533ec200a36ab6c273ace47fca1a7967bbec8fdb349115f19e83b`),
+				CreatedAt:        created,
+				ExpiresAt:        expires,
+			},
+		},
+		"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+	)
+
+	cases := []struct {
+		name        string
+		id          string
+		expected    user.Token
+		expectedErr bool
+	}{
+		{
+			name: "full id",
+			id:   "0c5b1f2e",
+			expected: user.Token{
+				ID:        "0c5b1f2e",
+				CreatedAt: created,
+				ExpiresAt: expires,
+				Current:   false,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "prefix of bytea hash",
+			id:   "53",
+			expected: user.Token{
+				ID:        "53cc8fd7",
+				CreatedAt: created,
+				ExpiresAt: expires,
+				Current:   true,
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "ambiguous",
+			id:          "0c5b",
+			expected:    user.Token{}, //nolint:exhaustruct
+			expectedErr: true,
+		},
+		{
+			name:        "prefix of the secret",
+			id:          "a1b2c3d4",
+			expected:    user.Token{}, //nolint:exhaustruct
+			expectedErr: true,
+		},
+		{
+			name:        "not found",
+			id:          "ffff",
+			expected:    user.Token{}, //nolint:exhaustruct
+			expectedErr: true,
+		},
+		{
+			name:        "empty",
+			id:          "",
+			expected:    user.Token{}, //nolint:exhaustruct
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := user.FindToken(tokens, tc.id)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expected, got, cmpopts.IgnoreUnexported(user.Token{})); diff != "" {
+				t.Errorf("FindToken() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
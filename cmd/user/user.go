@@ -1 +1,39 @@
 package user
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/nhostclient/credentials"
+	"github.com/urfave/cli/v2"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "user",
+		Aliases: []string{},
+		Usage:   "Manage your Nhost account and credentials",
+		Subcommands: []*cli.Command{
+			CommandLogin(),
+			CommandLogout(),
+			CommandTokens(),
+			CommandWhoami(),
+		},
+	}
+}
+
+// loadCredentials returns the stored credentials, unlike ce.LoadSession it
+// doesn't ask to login if there are none.
+func loadCredentials(ce *clienv.CliEnv) (credentials.Credentials, error) {
+	var creds credentials.Credentials
+	if !clienv.PathExists(ce.Path.AuthFile()) {
+		return creds, clienv.NewError(
+			clienv.KindAuthFailed, fmt.Errorf("not logged in"), //nolint:goerr113
+		)
+	}
+	if err := clienv.UnmarshalFile(ce.Path.AuthFile(), &creds, json.Unmarshal); err != nil {
+		return creds, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	return creds, nil
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/nhostclient/graphql"
+	"github.com/urfave/cli/v2"
+)
+
+func CommandWhoami() *cli.Command {
+	return &cli.Command{ //nolint:exhaustruct
+		Name:    "whoami",
+		Aliases: []string{},
+		Usage:   "Show the logged in user and their workspaces",
+		Action:  commandWhoami,
+	}
+}
+
+type Whoami struct {
+	Email      string   `json:"email"`
+	Context    string   `json:"context"`
+	Domain     string   `json:"domain"`
+	Workspaces []string `json:"workspaces"`
+}
+
+func commandWhoami(cCtx *cli.Context) error {
+	ce := clienv.FromCLI(cCtx)
+
+	if _, err := loadCredentials(ce); err != nil {
+		return err
+	}
+
+	session, err := ce.LoadSession(cCtx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	workspaces, err := ce.GetNhostClient().GetWorkspacesApps(
+		cCtx.Context,
+		graphql.WithAccessToken(session.Session.AccessToken),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get workspaces: %w", err)
+	}
+
+	whoami := Whoami{
+		Email:      session.Session.User.Email,
+		Context:    ce.ContextName(),
+		Domain:     ce.Domain(),
+		Workspaces: make([]string, 0, len(workspaces.Workspaces)),
+	}
+	for _, ws := range workspaces.Workspaces {
+		whoami.Workspaces = append(whoami.Workspaces, ws.Name)
+	}
+
+	return ce.PrintData(whoami, func() { //nolint:wrapcheck
+		ce.Println("Email:      %s", whoami.Email)
+		ce.Println("Context:    %s (%s)", whoami.Context, whoami.Domain)
+		ce.Println("Workspaces: %s", strings.Join(whoami.Workspaces, ", "))
+	})
+}
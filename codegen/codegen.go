@@ -0,0 +1,190 @@
+/*
+This package generates typed clients from GraphQL operations and the schema
+they run against.
+*/
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const header = "Code generated by nhost graphql codegen. DO NOT EDIT."
+
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+type Lang string
+
+const (
+	LangTypeScript Lang = "ts"
+	LangGo         Lang = "go"
+)
+
+func isOperationFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".graphql" || ext == ".gql"
+}
+
+// operationFiles returns the files in paths, directories are walked looking
+// for .graphql and .gql files.
+func operationFiles(paths []string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		if !fi.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isOperationFile(p) {
+				files = append(files, p)
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", path, err)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// LoadDocument parses the operations and fragments in the given files or
+// directories into a single document.
+func LoadDocument(paths []string) (*ast.QueryDocument, error) {
+	files, err := operationFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ast.QueryDocument{} //nolint:exhaustruct
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		d, err := parser.ParseQuery(&ast.Source{Name: file, Input: string(b), BuiltIn: false})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		for _, op := range d.Operations {
+			if op.Name != "" && doc.Operations.ForName(op.Name) != nil {
+				return nil, fmt.Errorf("%s: duplicated operation %s", file, op.Name) //nolint:goerr113
+			}
+			doc.Operations = append(doc.Operations, op)
+		}
+		for _, f := range d.Fragments {
+			if doc.Fragments.ForName(f.Name) != nil {
+				return nil, fmt.Errorf("%s: duplicated fragment %s", file, f.Name) //nolint:goerr113
+			}
+			doc.Fragments = append(doc.Fragments, f)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("no operations found") //nolint:goerr113
+	}
+
+	return doc, nil
+}
+
+// Generate resolves the operations in doc against the schema and returns the
+// generated code. pkg is the package name used for Go code.
+func Generate(schema *ast.Schema, doc *ast.QueryDocument, lang Lang, pkg string) ([]byte, error) {
+	res, err := resolve(schema, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch lang {
+	case LangTypeScript:
+		return generateTypeScript(res), nil
+	case LangGo:
+		return generateGo(res, pkg)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, lang)
+	}
+}
+
+func operationSuffix(kind ast.Operation) string {
+	switch kind {
+	case ast.Mutation:
+		return "Mutation"
+	case ast.Subscription:
+		return "Subscription"
+	default:
+		return "Query"
+	}
+}
+
+//nolint:gochecknoglobals
+var initialisms = map[string]bool{
+	"api": true, "http": true, "https": true, "id": true, "ip": true, "json": true,
+	"jwt": true, "sql": true, "uri": true, "url": true, "uuid": true,
+}
+
+// words splits an identifier on non alphanumeric characters and on case
+// changes, so user_id and userId both return user and id.
+func words(s string) []string {
+	res := make([]string, 0)
+	current := make([]rune, 0, len(s))
+	flush := func() {
+		if len(current) > 0 {
+			res = append(res, string(current))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return res
+}
+
+// exportedName converts a GraphQL name into an exported Go identifier.
+func exportedName(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		b.WriteString(strings.ToUpper(string(r[0])) + string(r[1:]))
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
@@ -0,0 +1,112 @@
+package codegen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/codegen"
+	"github.com/nhost/cli/gqlschema"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	schema, err := gqlschema.Load("testdata/schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := codegen.LoadDocument([]string{"testdata/operations"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		lang   codegen.Lang
+		golden string
+	}{
+		{lang: codegen.LangTypeScript, golden: "testdata/expected.ts"},
+		{lang: codegen.LangGo, golden: "testdata/expected.go"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(string(tc.lang), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := codegen.Generate(schema, doc, tc.lang, "generated")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := os.ReadFile(tc.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(string(expected), string(got)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Parallel()
+
+	schema, err := gqlschema.Load("testdata/schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		operation string
+		expected  string
+	}{
+		{
+			name:      "unknown field",
+			operation: "query GetTodos { todos { id priority } }",
+			expected:  "operation GetTodos: todos: field priority not found in type todos",
+		},
+		{
+			name:      "missing subfields",
+			operation: "query GetTodos { todos }",
+			expected:  "operation GetTodos: field todos of type todos must have a selection of subfields",
+		},
+		{
+			name:      "unknown variable type",
+			operation: "query GetTodos($where: todo_bool_exp) { todos(where: $where) { id } }",
+			expected:  "operation GetTodos: variable $where: todo_bool_exp isn't an input type",
+		},
+		{
+			name:      "anonymous operation",
+			operation: "{ todos { id } }",
+			expected:  "operation : anonymous operations aren't supported",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "operation.graphql")
+			if err := os.WriteFile(path, []byte(tc.operation), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := codegen.LoadDocument([]string{path})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = codegen.Generate(schema, doc, codegen.LangTypeScript, "")
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
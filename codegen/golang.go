@@ -0,0 +1,246 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//nolint:gochecknoglobals
+var goScalars = map[string]string{
+	"String":      "string",
+	"ID":          "string",
+	"Int":         "int",
+	"Float":       "float64",
+	"Boolean":     "bool",
+	"uuid":        "string",
+	"citext":      "string",
+	"bpchar":      "string",
+	"bytea":       "string",
+	"name":        "string",
+	"text":        "string",
+	"inet":        "string",
+	"interval":    "string",
+	"date":        "string",
+	"time":        "string",
+	"timetz":      "string",
+	"timestamp":   "string",
+	"timestamptz": "string",
+	"smallint":    "int",
+	"bigint":      "int64",
+	"numeric":     "float64",
+	"float4":      "float64",
+	"float8":      "float64",
+}
+
+const goPreamble = `
+// Doer sends a GraphQL operation and unmarshals the data in the response
+// into response.
+type Doer interface {
+	Do(ctx context.Context, query string, variables any, response any) error
+}
+
+// Client is a minimal Doer sending operations over HTTP.
+type Client struct {
+	URL        string
+	Header     http.Header
+	HTTPClient *http.Client
+}
+
+type graphqlError struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func (c *Client) Do(ctx context.Context, query string, variables any, response any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage ` + "`json:\"data\"`" + `
+		Errors []graphqlError   ` + "`json:\"errors\"`" + `
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response, status code %d: %w", resp.StatusCode, err)
+	}
+
+	if len(result.Errors) > 0 {
+		msgs := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			msgs[i] = e.Message
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	if err := json.Unmarshal(result.Data, response); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	return nil
+}
+`
+
+func goNamedType(def *ast.Definition) string {
+	switch def.Kind { //nolint:exhaustive
+	case ast.Scalar:
+		if t, ok := goScalars[def.Name]; ok {
+			return t
+		}
+		return "json.RawMessage"
+	default:
+		return exportedName(def.Name)
+	}
+}
+
+// goType returns the Go type for t, nullable values and values that may be
+// missing are pointers unless they are slices already.
+func goType(t *ast.Type, named string, optional bool) string {
+	if t.Elem != nil {
+		return "[]" + goType(t.Elem, named, false)
+	}
+	if (!t.NonNull || optional) && named != "json.RawMessage" {
+		return "*" + named
+	}
+	return named
+}
+
+func goTag(name string, omitempty bool) string {
+	if omitempty {
+		return fmt.Sprintf("`json:\"%s,omitempty\"`", name)
+	}
+	return fmt.Sprintf("`json:\"%s\"`", name)
+}
+
+func goRawString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+func goSelections(b *strings.Builder, typeName string, fields []*selection) {
+	nested := make([]*selection, 0)
+
+	fmt.Fprintf(b, "\ntype %s struct {\n", typeName)
+	for _, f := range fields {
+		named := goNamedType(f.def)
+		if f.fields != nil {
+			named = typeName + exportedName(f.name)
+			nested = append(nested, f)
+		}
+		fmt.Fprintf(
+			b,
+			"\t%s %s %s\n",
+			exportedName(f.name),
+			goType(f.typ, named, f.optional),
+			goTag(f.name, f.optional),
+		)
+	}
+	b.WriteString("}\n")
+
+	for _, f := range nested {
+		goSelections(b, typeName+exportedName(f.name), f.fields)
+	}
+}
+
+func generateGo(res *resolved, pkg string) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n\n", header, pkg)
+	b.WriteString(`import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+`)
+	b.WriteString(goPreamble)
+
+	for _, def := range res.enums {
+		name := exportedName(def.Name)
+		fmt.Fprintf(&b, "\ntype %s string\n\nconst (\n", name)
+		for _, v := range def.EnumValues {
+			fmt.Fprintf(&b, "\t%s%s %s = %q\n", name, exportedName(v.Name), name, v.Name)
+		}
+		b.WriteString(")\n")
+	}
+
+	for _, def := range res.inputs {
+		fmt.Fprintf(&b, "\ntype %s struct {\n", exportedName(def.Name))
+		for _, f := range def.Fields {
+			fmt.Fprintf(
+				&b,
+				"\t%s %s %s\n",
+				exportedName(f.Name),
+				goType(f.Type, goNamedType(res.definition(f.Type)), false),
+				goTag(f.Name, !f.Type.NonNull || f.DefaultValue != nil),
+			)
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, op := range res.operations {
+		name := exportedName(op.name)
+
+		fmt.Fprintf(&b, "\ntype %sVariables struct {\n", name)
+		for _, v := range op.variables {
+			fmt.Fprintf(
+				&b,
+				"\t%s %s %s\n",
+				exportedName(v.name),
+				goType(v.typ, goNamedType(v.def), false),
+				goTag(v.name, !v.typ.NonNull || v.hasDefault),
+			)
+		}
+		b.WriteString("}\n")
+
+		goSelections(&b, name+"Response", op.fields)
+
+		fmt.Fprintf(&b, "\nconst %sDocument = %s\n", name, goRawString(op.document))
+
+		fmt.Fprintf(&b, `
+func %[1]s(
+	ctx context.Context, client Doer, variables %[1]sVariables,
+) (*%[1]sResponse, error) {
+	var response %[1]sResponse
+	if err := client.Do(ctx, %[1]sDocument, variables, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+`, name)
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
@@ -0,0 +1,327 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// selection is a field in the response of an operation with its type resolved
+// against the schema.
+type selection struct {
+	name     string
+	typ      *ast.Type
+	def      *ast.Definition
+	optional bool
+	fields   []*selection
+}
+
+type variable struct {
+	name       string
+	typ        *ast.Type
+	def        *ast.Definition
+	hasDefault bool
+}
+
+type operation struct {
+	name      string
+	kind      ast.Operation
+	document  string
+	variables []variable
+	fields    []*selection
+}
+
+type resolved struct {
+	schema     *ast.Schema
+	operations []operation
+	// enums and inputs are the named types referenced by the operations that
+	// need to be generated, sorted by name.
+	enums  []*ast.Definition
+	inputs []*ast.Definition
+}
+
+type resolver struct {
+	schema    *ast.Schema
+	doc       *ast.QueryDocument
+	fragments map[string]bool
+	visiting  map[string]bool
+	enums     map[string]*ast.Definition
+	inputs    map[string]*ast.Definition
+}
+
+func resolve(schema *ast.Schema, doc *ast.QueryDocument) (*resolved, error) {
+	r := &resolver{
+		schema:    schema,
+		doc:       doc,
+		fragments: nil,
+		visiting:  nil,
+		enums:     make(map[string]*ast.Definition),
+		inputs:    make(map[string]*ast.Definition),
+	}
+
+	operations := make([]operation, 0, len(doc.Operations))
+	for _, op := range doc.Operations {
+		o, err := r.operation(op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.Name, err)
+		}
+		operations = append(operations, o)
+	}
+
+	return &resolved{
+		schema:     schema,
+		operations: operations,
+		enums:      sorted(r.enums),
+		inputs:     sorted(r.inputs),
+	}, nil
+}
+
+// definition returns the definition of the named type, unknown types are
+// treated as custom scalars.
+func (r *resolved) definition(t *ast.Type) *ast.Definition {
+	if def, ok := r.schema.Types[t.Name()]; ok {
+		return def
+	}
+	return &ast.Definition{Kind: ast.Scalar, Name: t.Name()} //nolint:exhaustruct
+}
+
+func sorted(defs map[string]*ast.Definition) []*ast.Definition {
+	s := make([]*ast.Definition, 0, len(defs))
+	for _, def := range defs {
+		s = append(s, def)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+	return s
+}
+
+func (r *resolver) root(kind ast.Operation) (*ast.Definition, error) {
+	var def *ast.Definition
+	switch kind {
+	case ast.Query:
+		def = r.schema.Query
+	case ast.Mutation:
+		def = r.schema.Mutation
+	case ast.Subscription:
+		def = r.schema.Subscription
+	}
+	if def == nil {
+		return nil, fmt.Errorf("schema doesn't support %ss", kind) //nolint:goerr113
+	}
+	return def, nil
+}
+
+func (r *resolver) operation(op *ast.OperationDefinition) (operation, error) {
+	if op.Name == "" {
+		return operation{}, fmt.Errorf("anonymous operations aren't supported") //nolint:goerr113
+	}
+
+	root, err := r.root(op.Operation)
+	if err != nil {
+		return operation{}, err
+	}
+
+	r.fragments = make(map[string]bool)
+	r.visiting = make(map[string]bool)
+
+	variables := make([]variable, len(op.VariableDefinitions))
+	for i, v := range op.VariableDefinitions {
+		def, ok := r.schema.Types[v.Type.Name()]
+		if !ok || !def.IsInputType() {
+			return operation{}, fmt.Errorf( //nolint:goerr113
+				"variable $%s: %s isn't an input type", v.Variable, v.Type.Name(),
+			)
+		}
+		r.addInputType(def)
+		variables[i] = variable{
+			name:       v.Variable,
+			typ:        v.Type,
+			def:        def,
+			hasDefault: v.DefaultValue != nil,
+		}
+	}
+
+	fields, err := r.selectionSet(root, op.SelectionSet, false)
+	if err != nil {
+		return operation{}, err
+	}
+
+	return operation{
+		name:      op.Name,
+		kind:      op.Operation,
+		document:  r.document(op),
+		variables: variables,
+		fields:    fields,
+	}, nil
+}
+
+// document returns the operation formatted together with the fragments it uses.
+func (r *resolver) document(op *ast.OperationDefinition) string {
+	doc := &ast.QueryDocument{ //nolint:exhaustruct
+		Operations: ast.OperationList{op},
+	}
+	for _, f := range r.doc.Fragments {
+		if r.fragments[f.Name] {
+			doc.Fragments = append(doc.Fragments, f)
+		}
+	}
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatQueryDocument(doc)
+	return buf.String()
+}
+
+func (r *resolver) addInputType(def *ast.Definition) {
+	switch def.Kind { //nolint:exhaustive
+	case ast.Enum:
+		r.enums[def.Name] = def
+	case ast.InputObject:
+		if _, ok := r.inputs[def.Name]; ok {
+			return
+		}
+		r.inputs[def.Name] = def
+		for _, f := range def.Fields {
+			if d, ok := r.schema.Types[f.Type.Name()]; ok {
+				r.addInputType(d)
+			}
+		}
+	}
+}
+
+func (r *resolver) selectionSet(
+	parent *ast.Definition, set ast.SelectionSet, optional bool,
+) ([]*selection, error) {
+	fields := make([]*selection, 0, len(set))
+	for _, s := range set {
+		switch s := s.(type) {
+		case *ast.Field:
+			f, err := r.field(parent, s, optional)
+			if err != nil {
+				return nil, err
+			}
+			fields = merge(fields, f)
+		case *ast.InlineFragment:
+			typeCondition := s.TypeCondition
+			if typeCondition == "" {
+				typeCondition = parent.Name
+			}
+			sub, err := r.fragment(parent, typeCondition, s.SelectionSet, optional)
+			if err != nil {
+				return nil, err
+			}
+			fields = merge(fields, sub...)
+		case *ast.FragmentSpread:
+			frag := r.doc.Fragments.ForName(s.Name)
+			if frag == nil {
+				return nil, fmt.Errorf("fragment %s not found", s.Name) //nolint:goerr113
+			}
+			if r.visiting[s.Name] {
+				return nil, fmt.Errorf("fragment %s references itself", s.Name) //nolint:goerr113
+			}
+			r.fragments[s.Name] = true
+			r.visiting[s.Name] = true
+			sub, err := r.fragment(parent, frag.TypeCondition, frag.SelectionSet, optional)
+			delete(r.visiting, s.Name)
+			if err != nil {
+				return nil, err
+			}
+			fields = merge(fields, sub...)
+		}
+	}
+	return fields, nil
+}
+
+// fragment resolves the selection set of a fragment. Its fields are optional
+// if the fragment only applies to some of the types the parent can be.
+func (r *resolver) fragment(
+	parent *ast.Definition, typeCondition string, set ast.SelectionSet, optional bool,
+) ([]*selection, error) {
+	def, ok := r.schema.Types[typeCondition]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", typeCondition) //nolint:goerr113
+	}
+	return r.selectionSet(def, set, optional || def.Name != parent.Name)
+}
+
+func conditional(directives ast.DirectiveList) bool {
+	return directives.ForName("include") != nil || directives.ForName("skip") != nil
+}
+
+func (r *resolver) field(parent *ast.Definition, f *ast.Field, optional bool) (*selection, error) {
+	name := f.Alias
+	if name == "" {
+		name = f.Name
+	}
+
+	if f.Name == "__typename" {
+		return &selection{
+			name:     name,
+			typ:      ast.NonNullNamedType("String", nil),
+			def:      &ast.Definition{Kind: ast.Scalar, Name: "String"}, //nolint:exhaustruct
+			optional: optional,
+			fields:   nil,
+		}, nil
+	}
+
+	fd := parent.Fields.ForName(f.Name)
+	if fd == nil {
+		return nil, fmt.Errorf( //nolint:goerr113
+			"field %s not found in type %s", f.Name, parent.Name,
+		)
+	}
+
+	def, ok := r.schema.Types[fd.Type.Name()]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", fd.Type.Name()) //nolint:goerr113
+	}
+
+	sel := &selection{
+		name:     name,
+		typ:      fd.Type,
+		def:      def,
+		optional: optional || conditional(f.Directives),
+		fields:   nil,
+	}
+
+	switch {
+	case def.IsCompositeType() && len(f.SelectionSet) == 0:
+		return nil, fmt.Errorf( //nolint:goerr113
+			"field %s of type %s must have a selection of subfields", name, def.Name,
+		)
+	case def.IsLeafType() && len(f.SelectionSet) > 0:
+		return nil, fmt.Errorf( //nolint:goerr113
+			"field %s of type %s can't have a selection of subfields", name, def.Name,
+		)
+	case def.IsCompositeType():
+		fields, err := r.selectionSet(def, f.SelectionSet, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		sel.fields = fields
+	case def.Kind == ast.Enum:
+		r.enums[def.Name] = def
+	}
+
+	return sel, nil
+}
+
+// merge adds the selections to fields merging the ones with the same name.
+func merge(fields []*selection, selections ...*selection) []*selection {
+	for _, s := range selections {
+		found := false
+		for _, f := range fields {
+			if f.name == s.name {
+				f.optional = f.optional && s.optional
+				f.fields = merge(f.fields, s.fields...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			fields = append(fields, s)
+		}
+	}
+	return fields
+}
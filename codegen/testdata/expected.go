@@ -0,0 +1,168 @@
+// Code generated by nhost graphql codegen. DO NOT EDIT.
+
+package generated
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Doer sends a GraphQL operation and unmarshals the data in the response
+// into response.
+type Doer interface {
+	Do(ctx context.Context, query string, variables any, response any) error
+}
+
+// Client is a minimal Doer sending operations over HTTP.
+type Client struct {
+	URL        string
+	Header     http.Header
+	HTTPClient *http.Client
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+func (c *Client) Do(ctx context.Context, query string, variables any, response any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphqlError  `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response, status code %d: %w", resp.StatusCode, err)
+	}
+
+	if len(result.Errors) > 0 {
+		msgs := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			msgs[i] = e.Message
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	if err := json.Unmarshal(result.Data, response); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	return nil
+}
+
+type StringComparisonExp struct {
+	Eq  *string `json:"_eq,omitempty"`
+	Neq *string `json:"_neq,omitempty"`
+}
+
+type TodosBoolExp struct {
+	And   []TodosBoolExp       `json:"_and,omitempty"`
+	Not   *TodosBoolExp        `json:"_not,omitempty"`
+	Title *StringComparisonExp `json:"title,omitempty"`
+}
+
+type GetTodosVariables struct {
+	Where *TodosBoolExp `json:"where,omitempty"`
+	Limit *int          `json:"limit,omitempty"`
+}
+
+type GetTodosResponse struct {
+	Todos []GetTodosResponseTodos `json:"todos"`
+}
+
+type GetTodosResponseTodos struct {
+	ID    string                     `json:"id"`
+	Title string                     `json:"title"`
+	Done  *bool                      `json:"done,omitempty"`
+	User  *GetTodosResponseTodosUser `json:"user"`
+}
+
+type GetTodosResponseTodosUser struct {
+	ID          string          `json:"id"`
+	DisplayName string          `json:"displayName"`
+	Metadata    json.RawMessage `json:"metadata"`
+}
+
+const GetTodosDocument = `query GetTodos ($where: todos_bool_exp, $limit: Int = 10) {
+  todos(where: $where, limit: $limit, order_by: [{title:asc}]) {
+    id
+    title
+    done @include(if: true)
+    user {
+      ... UserFields
+      metadata
+    }
+  }
+}
+fragment UserFields on users {
+  id
+  displayName
+}
+`
+
+func GetTodos(
+	ctx context.Context, client Doer, variables GetTodosVariables,
+) (*GetTodosResponse, error) {
+	var response GetTodosResponse
+	if err := client.Do(ctx, GetTodosDocument, variables, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+type DeleteTodoVariables struct {
+	ID string `json:"id"`
+}
+
+type DeleteTodoResponse struct {
+	DeleteTodosByPk *DeleteTodoResponseDeleteTodosByPk `json:"delete_todos_by_pk"`
+}
+
+type DeleteTodoResponseDeleteTodosByPk struct {
+	ID string `json:"id"`
+}
+
+const DeleteTodoDocument = `mutation DeleteTodo ($id: uuid!) {
+  delete_todos_by_pk(id: $id) {
+    id
+  }
+}
+`
+
+func DeleteTodo(
+	ctx context.Context, client Doer, variables DeleteTodoVariables,
+) (*DeleteTodoResponse, error) {
+	var response DeleteTodoResponse
+	if err := client.Do(ctx, DeleteTodoDocument, variables, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
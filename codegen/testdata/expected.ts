@@ -0,0 +1,89 @@
+// Code generated by nhost graphql codegen. DO NOT EDIT.
+
+export type TypedDocument<TResult, TVariables> = string & {
+  __result?: TResult;
+  __variables?: TVariables;
+};
+
+export async function request<TResult, TVariables>(
+  url: string,
+  document: TypedDocument<TResult, TVariables>,
+  variables: TVariables,
+  headers: Record<string, string> = {},
+): Promise<TResult> {
+  const response = await fetch(url, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json', ...headers },
+    body: JSON.stringify({ query: document, variables }),
+  });
+
+  const { data, errors } = await response.json();
+  if (errors?.length) {
+    throw new Error(errors.map((e: { message: string }) => e.message).join('; '));
+  }
+
+  return data as TResult;
+}
+
+export type String_comparison_exp = {
+  _eq?: string | null;
+  _neq?: string | null;
+};
+
+export type todos_bool_exp = {
+  _and?: Array<todos_bool_exp> | null;
+  _not?: todos_bool_exp | null;
+  title?: String_comparison_exp | null;
+};
+
+export type GetTodosQueryVariables = {
+  where?: todos_bool_exp | null;
+  limit?: number | null;
+};
+
+export type GetTodosQuery = {
+  todos: Array<{
+    id: string;
+    title: string;
+    done?: boolean | null;
+    user: {
+      id: string;
+      displayName: string;
+      metadata: any | null;
+    } | null;
+  }>;
+};
+
+export const GetTodosDocument = `query GetTodos ($where: todos_bool_exp, $limit: Int = 10) {
+  todos(where: $where, limit: $limit, order_by: [{title:asc}]) {
+    id
+    title
+    done @include(if: true)
+    user {
+      ... UserFields
+      metadata
+    }
+  }
+}
+fragment UserFields on users {
+  id
+  displayName
+}
+` as TypedDocument<GetTodosQuery, GetTodosQueryVariables>;
+
+export type DeleteTodoMutationVariables = {
+  id: string;
+};
+
+export type DeleteTodoMutation = {
+  delete_todos_by_pk: {
+    id: string;
+  } | null;
+};
+
+export const DeleteTodoDocument = `mutation DeleteTodo ($id: uuid!) {
+  delete_todos_by_pk(id: $id) {
+    id
+  }
+}
+` as TypedDocument<DeleteTodoMutation, DeleteTodoMutationVariables>;
@@ -0,0 +1,185 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//nolint:gochecknoglobals
+var tsScalars = map[string]string{
+	"String":      "string",
+	"ID":          "string",
+	"Int":         "number",
+	"Float":       "number",
+	"Boolean":     "boolean",
+	"uuid":        "string",
+	"citext":      "string",
+	"bpchar":      "string",
+	"bytea":       "string",
+	"name":        "string",
+	"text":        "string",
+	"inet":        "string",
+	"interval":    "string",
+	"date":        "string",
+	"time":        "string",
+	"timetz":      "string",
+	"timestamp":   "string",
+	"timestamptz": "string",
+	"smallint":    "number",
+	"bigint":      "number",
+	"numeric":     "number",
+	"float4":      "number",
+	"float8":      "number",
+	"json":        "any",
+	"jsonb":       "any",
+}
+
+const tsPreamble = `export type TypedDocument<TResult, TVariables> = string & {
+  __result?: TResult;
+  __variables?: TVariables;
+};
+
+export async function request<TResult, TVariables>(
+  url: string,
+  document: TypedDocument<TResult, TVariables>,
+  variables: TVariables,
+  headers: Record<string, string> = {},
+): Promise<TResult> {
+  const response = await fetch(url, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json', ...headers },
+    body: JSON.stringify({ query: document, variables }),
+  });
+
+  const { data, errors } = await response.json();
+  if (errors?.length) {
+    throw new Error(errors.map((e: { message: string }) => e.message).join('; '));
+  }
+
+  return data as TResult;
+}
+`
+
+func tsNamedType(def *ast.Definition) string {
+	switch def.Kind { //nolint:exhaustive
+	case ast.Scalar:
+		if t, ok := tsScalars[def.Name]; ok {
+			return t
+		}
+		return "unknown"
+	default:
+		return def.Name
+	}
+}
+
+func tsType(t *ast.Type, named string) string {
+	var s string
+	if t.Elem != nil {
+		s = "Array<" + tsType(t.Elem, named) + ">"
+	} else {
+		s = named
+	}
+
+	if !t.NonNull {
+		s += " | null"
+	}
+	return s
+}
+
+func tsSelections(b *strings.Builder, fields []*selection, indent string) {
+	b.WriteString("{\n")
+	for _, f := range fields {
+		named := tsNamedType(f.def)
+		if f.fields != nil {
+			var nested strings.Builder
+			tsSelections(&nested, f.fields, indent+"  ")
+			named = nested.String()
+		}
+
+		optional := ""
+		if f.optional {
+			optional = "?"
+		}
+		fmt.Fprintf(b, "%s  %s%s: %s;\n", indent, f.name, optional, tsType(f.typ, named))
+	}
+	b.WriteString(indent + "}")
+}
+
+func tsOptional(t *ast.Type, hasDefault bool) string {
+	if !t.NonNull || hasDefault {
+		return "?"
+	}
+	return ""
+}
+
+func tsTemplateLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "`", "\\`")
+	s = strings.ReplaceAll(s, "${", "\\${")
+	return "`" + s + "`"
+}
+
+func generateTypeScript(res *resolved) []byte {
+	var b strings.Builder
+	b.WriteString("// " + header + "\n\n")
+	b.WriteString(tsPreamble)
+
+	for _, def := range res.enums {
+		values := make([]string, len(def.EnumValues))
+		for i, v := range def.EnumValues {
+			values[i] = "'" + v.Name + "'"
+		}
+		fmt.Fprintf(&b, "\nexport type %s = %s;\n", def.Name, strings.Join(values, " | "))
+	}
+
+	for _, def := range res.inputs {
+		fmt.Fprintf(&b, "\nexport type %s = {\n", def.Name)
+		for _, f := range def.Fields {
+			fmt.Fprintf(
+				&b,
+				"  %s%s: %s;\n",
+				f.Name,
+				tsOptional(f.Type, f.DefaultValue != nil),
+				tsType(f.Type, tsNamedType(res.definition(f.Type))),
+			)
+		}
+		b.WriteString("};\n")
+	}
+
+	for _, op := range res.operations {
+		name := exportedName(op.name) + operationSuffix(op.kind)
+
+		if len(op.variables) == 0 {
+			fmt.Fprintf(&b, "\nexport type %sVariables = Record<string, never>;\n", name)
+		} else {
+			fmt.Fprintf(&b, "\nexport type %sVariables = {\n", name)
+			for _, v := range op.variables {
+				fmt.Fprintf(
+					&b,
+					"  %s%s: %s;\n",
+					v.name,
+					tsOptional(v.typ, v.hasDefault),
+					tsType(v.typ, tsNamedType(v.def)),
+				)
+			}
+			b.WriteString("};\n")
+		}
+
+		fmt.Fprintf(&b, "\nexport type %s = ", name)
+		tsSelections(&b, op.fields, "")
+		b.WriteString(";\n")
+
+		fmt.Fprintf(
+			&b,
+			"\nexport const %sDocument = %s as TypedDocument<%s, %sVariables>;\n",
+			exportedName(op.name),
+			tsTemplateLiteral(op.document),
+			name,
+			name,
+		)
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,56 @@
+package dockercompose
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const appIngressFile = "app.yaml"
+
+const appIngressConfig = `# DO NOT EDIT THIS FILE
+http:
+  routers:
+    app:
+      entryPoints:
+        - web
+      rule: Host(` + "`local.app.nhost.run`" + `)
+      service: app%s
+  services:
+    app:
+      loadBalancer:
+        servers:
+          - url: http://host.docker.internal:%d
+`
+
+// WriteAppIngress configures traefik to route local.app.nhost.run to a
+// server listening on port in the host, i.e. a frontend dev server.
+func WriteAppIngress(dotnhostfolder string, port uint, useTLS bool) error {
+	tls := ""
+	if useTLS {
+		tls = "\n      tls: {}"
+	}
+
+	if err := os.MkdirAll(filepath.Join(dotnhostfolder, "traefik"), 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create traefik folder: %w", err)
+	}
+
+	if err := os.WriteFile(
+		filepath.Join(dotnhostfolder, "traefik", appIngressFile),
+		[]byte(fmt.Sprintf(appIngressConfig, tls, port)),
+		0o644, //nolint:gomnd,gosec
+	); err != nil {
+		return fmt.Errorf("failed to write %s: %w", appIngressFile, err)
+	}
+	return nil
+}
+
+// RemoveAppIngress removes the route created by WriteAppIngress, if any.
+func RemoveAppIngress(dotnhostfolder string) error {
+	err := os.Remove(filepath.Join(dotnhostfolder, "traefik", appIngressFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", appIngressFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteAppIngress(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		useTLS   bool
+		expected string
+	}{
+		{
+			name:   "tls",
+			useTLS: true,
+			expected: "# DO NOT EDIT THIS FILE\n" +
+				"http:\n" +
+				"  routers:\n" +
+				"    app:\n" +
+				"      entryPoints:\n" +
+				"        - web\n" +
+				"      rule: Host(`local.app.nhost.run`)\n" +
+				"      service: app\n" +
+				"      tls: {}\n" +
+				"  services:\n" +
+				"    app:\n" +
+				"      loadBalancer:\n" +
+				"        servers:\n" +
+				"          - url: http://host.docker.internal:3001\n",
+		},
+		{
+			name:   "no tls",
+			useTLS: false,
+			expected: "# DO NOT EDIT THIS FILE\n" +
+				"http:\n" +
+				"  routers:\n" +
+				"    app:\n" +
+				"      entryPoints:\n" +
+				"        - web\n" +
+				"      rule: Host(`local.app.nhost.run`)\n" +
+				"      service: app\n" +
+				"  services:\n" +
+				"    app:\n" +
+				"      loadBalancer:\n" +
+				"        servers:\n" +
+				"          - url: http://host.docker.internal:3001\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dotnhost := t.TempDir()
+			if err := WriteAppIngress(dotnhost, 3001, tc.useTLS); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			b, err := os.ReadFile(filepath.Join(dotnhost, "traefik", "app.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read app.yaml: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, string(b)); diff != "" {
+				t.Errorf("app.yaml mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := RemoveAppIngress(dotnhost); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if err := RemoveAppIngress(dotnhost); err != nil {
+				t.Fatalf("removing twice should succeed, got: %v", err)
+			}
+		})
+	}
+}
@@ -38,7 +38,8 @@ func auth( //nolint:funlen
 		env[v.Name] = v.Value
 	}
 	return &Service{
-		Image: fmt.Sprintf("nhost/hasura-auth:%s", *cfg.Auth.Version),
+		Image:  fmt.Sprintf("nhost/hasura-auth:%s", *cfg.Auth.Version),
+		Deploy: nil,
 		DependsOn: map[string]DependsOn{
 			"graphql": {
 				Condition: "service_healthy",
@@ -21,7 +21,7 @@ const (
 )
 
 const (
-	minimumHasuraVerson = "v2.18.0"
+	MinimumHasuraVersion = "v2.18.0"
 )
 
 func ptr[T any](v T) *T {
@@ -51,6 +51,7 @@ type ComposeFile struct {
 //nolint:tagliatelle
 type Service struct {
 	Image       string               `yaml:"image"`
+	Deploy      *Deploy              `yaml:"deploy,omitempty"`
 	DependsOn   map[string]DependsOn `yaml:"depends_on,omitempty"`
 	EntryPoint  []string             `yaml:"entrypoint,omitempty"`
 	Command     []string             `yaml:"command,omitempty"`
@@ -172,6 +173,7 @@ func traefik(projectName string, port uint, dotnhostfolder string) (*Service, er
 
 	return &Service{
 		Image:      "traefik:v2.8",
+		Deploy:     nil,
 		DependsOn:  nil,
 		EntryPoint: nil,
 		Command: []string{
@@ -223,6 +225,7 @@ func minio(dataFolder string) (*Service, error) {
 	}
 	return &Service{
 		Image:      "minio/minio:RELEASE.2022-07-08T00-05-23Z",
+		Deploy:     nil,
 		DependsOn:  nil,
 		EntryPoint: []string{"/bin/sh"},
 		Command: []string{
@@ -251,6 +254,7 @@ func minio(dataFolder string) (*Service, error) {
 func dashboard(cfg *model.ConfigConfig, httpPort uint, useTLS bool) *Service {
 	return &Service{
 		Image:      "nhost/dashboard:0.19.0",
+		Deploy:     nil,
 		DependsOn:  nil,
 		EntryPoint: nil,
 		Command:    nil,
@@ -290,14 +294,14 @@ func dashboard(cfg *model.ConfigConfig, httpPort uint, useTLS bool) *Service {
 	}
 }
 
-func functions( //nolint:funlen
+// FunctionsEnv returns the environment variables available to functions in the
+// local development environment.
+func FunctionsEnv(
 	cfg *model.ConfigConfig,
 	httpPort uint,
 	useTLS bool,
-	rootFolder string,
 	jwtSecret string,
-	port uint,
-) *Service {
+) map[string]string {
 	envVars := map[string]string{
 		"HASURA_GRAPHQL_ADMIN_SECRET": cfg.Hasura.AdminSecret,
 		"HASURA_GRAPHQL_DATABASE_URL": "postgres://nhost_auth_admin@local.db.nhost.run:5432/local",
@@ -317,12 +321,24 @@ func functions( //nolint:funlen
 	for _, envVar := range cfg.GetGlobal().GetEnvironment() {
 		envVars[envVar.GetName()] = envVar.GetValue()
 	}
+	return envVars
+}
+
+func functions( //nolint:funlen
+	cfg *model.ConfigConfig,
+	httpPort uint,
+	useTLS bool,
+	rootFolder string,
+	jwtSecret string,
+	port uint,
+) *Service {
 	return &Service{
 		Image:       "nhost/functions:0.1.9",
+		Deploy:      nil,
 		DependsOn:   nil,
 		EntryPoint:  nil,
 		Command:     nil,
-		Environment: envVars,
+		Environment: FunctionsEnv(cfg, httpPort, useTLS, jwtSecret),
 		ExtraHosts:  extraHosts(),
 		HealthCheck: &HealthCheck{
 			Test:        []string{"CMD", "wget", "--spider", "-S", "http://localhost:3000/healthz"},
@@ -373,6 +389,7 @@ func mailhog(dataFolder string, useTLS bool) (*Service, error) {
 
 	return &Service{
 		Image:      "jcalonso/mailhog:v1.0.1",
+		Deploy:     nil,
 		DependsOn:  nil,
 		EntryPoint: []string{},
 		Command:    []string{},
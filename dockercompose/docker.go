@@ -13,10 +13,20 @@ import (
 	"github.com/creack/pty"
 )
 
-type Docker struct{}
+type Docker struct {
+	stdout io.Writer
+}
 
 func NewDocker() *Docker {
-	return &Docker{}
+	return &Docker{
+		stdout: os.Stdout,
+	}
+}
+
+// SetStdout changes where the output of the containers is written to, by
+// default os.Stdout.
+func (d *Docker) SetStdout(w io.Writer) {
+	d.stdout = w
 }
 
 func (d *Docker) HasuraWrapper(
@@ -59,7 +69,7 @@ func (d *Docker) HasuraWrapper(
 	}
 	defer f.Close()
 
-	if n, err := io.Copy(os.Stdout, f); err != nil {
+	if n, err := io.Copy(d.stdout, f); err != nil {
 		var pathError *fs.PathError
 		switch {
 		case errors.As(err, &pathError) && n > 0 && pathError.Op == op:
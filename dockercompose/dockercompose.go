@@ -1,6 +1,7 @@
 package dockercompose
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,6 +9,8 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
+	"sort"
+	"strings"
 
 	"github.com/creack/pty"
 	"gopkg.in/yaml.v3"
@@ -15,10 +18,40 @@ import (
 
 const op = "read"
 
+// CommandError is returned when a docker compose command fails. It carries
+// what the command wrote to stderr so the failure can be diagnosed.
+type CommandError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// DockerUnavailable returns true if err was caused by docker not being
+// installed or its daemon not running.
+func DockerUnavailable(err error) bool {
+	var execErr *exec.Error
+	if errors.As(err, &execErr) && execErr.Name == "docker" {
+		return true
+	}
+
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr) &&
+		(strings.Contains(cmdErr.Stderr, "Cannot connect to the Docker daemon") ||
+			strings.Contains(cmdErr.Stderr, "error during connect"))
+}
+
 type DockerCompose struct {
 	workingDir  string
 	filepath    string
 	projectName string
+	stdout      io.Writer
 }
 
 func New(workingDir, filepath, projectName string) *DockerCompose {
@@ -26,9 +59,16 @@ func New(workingDir, filepath, projectName string) *DockerCompose {
 		workingDir:  workingDir,
 		filepath:    filepath,
 		projectName: projectName,
+		stdout:      os.Stdout,
 	}
 }
 
+// SetStdout changes where the output of docker compose and hasura-cli is
+// written to, by default os.Stdout.
+func (dc *DockerCompose) SetStdout(w io.Writer) {
+	dc.stdout = w
+}
+
 func (dc *DockerCompose) WriteComposeFile(composeFile *ComposeFile) error {
 	f, err := os.Create(dc.filepath)
 	if err != nil {
@@ -48,6 +88,28 @@ func (dc *DockerCompose) WriteComposeFile(composeFile *ComposeFile) error {
 	return nil
 }
 
+// Services returns the names of the services in the docker-compose file.
+func (dc *DockerCompose) Services() ([]string, error) {
+	b, err := os.ReadFile(dc.filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker-compose file: %w", err)
+	}
+
+	var composeFile struct {
+		Services map[string]yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(b, &composeFile); err != nil {
+		return nil, fmt.Errorf("failed to parse docker-compose file: %w", err)
+	}
+
+	services := make([]string, 0, len(composeFile.Services))
+	for name := range composeFile.Services {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
 func (dc *DockerCompose) Start(ctx context.Context) error {
 	cmd := exec.CommandContext( //nolint:gosec
 		ctx,
@@ -58,11 +120,15 @@ func (dc *DockerCompose) Start(ctx context.Context) error {
 		"up",
 		"-d", "--wait",
 	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	var stderr bytes.Buffer
+	cmd.Stdout = dc.stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to start docker compose: %w", err)
+		return fmt.Errorf(
+			"failed to start docker compose: %w",
+			&CommandError{Err: err, Stderr: stderr.String()},
+		)
 	}
 	return nil
 }
@@ -76,7 +142,7 @@ func (dc *DockerCompose) Stop(ctx context.Context) error {
 		"-p", dc.projectName,
 		"down",
 	)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = dc.stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
@@ -100,7 +166,7 @@ func (dc *DockerCompose) Logs(ctx context.Context, extraArgs ...string) error {
 		"docker",
 		args...,
 	)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = dc.stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
@@ -123,7 +189,7 @@ func (dc *DockerCompose) Wrapper(ctx context.Context, extraArgs ...string) error
 		"docker",
 		args...,
 	)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = dc.stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
@@ -153,7 +219,7 @@ func (dc *DockerCompose) ApplyMetadata(ctx context.Context) error {
 	}
 	defer f.Close()
 
-	if n, err := io.Copy(os.Stdout, f); err != nil {
+	if n, err := io.Copy(dc.stdout, f); err != nil {
 		var pathError *fs.PathError
 		switch {
 		case errors.As(err, &pathError) && n > 0 && pathError.Op == op:
@@ -190,7 +256,7 @@ func (dc *DockerCompose) ApplyMigrations(ctx context.Context) error {
 	}
 	defer f.Close()
 
-	if n, err := io.Copy(os.Stdout, f); err != nil {
+	if n, err := io.Copy(dc.stdout, f); err != nil {
 		var pathError *fs.PathError
 		switch {
 		case errors.As(err, &pathError) && n > 0 && pathError.Op == op:
@@ -226,7 +292,7 @@ func (dc *DockerCompose) ApplySeeds(ctx context.Context) error {
 	}
 	defer f.Close()
 
-	if n, err := io.Copy(os.Stdout, f); err != nil {
+	if n, err := io.Copy(dc.stdout, f); err != nil {
 		var pathError *fs.PathError
 		switch {
 		case errors.As(err, &pathError) && n > 0 && pathError.Op == op:
@@ -0,0 +1,61 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+var errExitStatus = errors.New("exit status 1") //nolint:goerr113
+
+func TestDockerUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "docker not installed",
+			err: fmt.Errorf(
+				"failed to start docker compose: %w",
+				&exec.Error{Name: "docker", Err: exec.ErrNotFound},
+			),
+			expected: true,
+		},
+		{
+			name:     "other binary not installed",
+			err:      &exec.Error{Name: "npm", Err: exec.ErrNotFound},
+			expected: false,
+		},
+		{
+			name: "daemon not running",
+			err: fmt.Errorf("failed to start docker compose: %w", &CommandError{
+				Err:    errExitStatus,
+				Stderr: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?\n", //nolint:lll
+			}),
+			expected: true,
+		},
+		{
+			name: "service failed",
+			err: &CommandError{
+				Err:    errExitStatus,
+				Stderr: "dependency failed to start: container local-auth-1 is unhealthy\n",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DockerUnavailable(tc.err); got != tc.expected {
+				t.Errorf("got %t, expected %t", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportComposeFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name             string
+		opts             ExportOptions
+		expectedServices []string
+		expectedVolumes  []string
+		expectedLabels   map[string]string
+	}{
+		{
+			name: "http",
+			opts: ExportOptions{
+				Domain:    "example.com",
+				ACMEEmail: "",
+				Functions: false,
+			},
+			expectedServices: []string{"auth", "graphql", "minio", "postgres", "storage", "traefik"},
+			expectedVolumes:  []string{"minio_data", "pgdata"},
+			expectedLabels: map[string]string{
+				"traefik.enable": "true",
+				"traefik.http.middlewares.replace-auth.replacepathregex.regex":       "/v1(/|$$)(.*)",
+				"traefik.http.middlewares.replace-auth.replacepathregex.replacement": "/$$2",
+				"traefik.http.routers.auth.entrypoints":                              "web",
+				"traefik.http.routers.auth.middlewares":                              "replace-auth",
+				"traefik.http.routers.auth.rule":                                     "Host(`auth.example.com`) && PathPrefix(`/v1`)",
+				"traefik.http.routers.auth.service":                                  "auth",
+				"traefik.http.routers.auth.tls":                                      "false",
+				"traefik.http.services.auth.loadbalancer.server.port":                "4000",
+			},
+		},
+		{
+			name: "tls with functions",
+			opts: ExportOptions{
+				Domain:    "example.com",
+				ACMEEmail: "admin@example.com",
+				Functions: true,
+			},
+			expectedServices: []string{
+				"auth", "functions", "graphql", "minio", "postgres", "storage", "traefik",
+			},
+			expectedVolumes: []string{
+				"functions_node_modules", "letsencrypt", "minio_data", "pgdata", "root_node_modules",
+			},
+			expectedLabels: map[string]string{
+				"traefik.enable": "true",
+				"traefik.http.middlewares.replace-auth.replacepathregex.regex":       "/v1(/|$$)(.*)",
+				"traefik.http.middlewares.replace-auth.replacepathregex.replacement": "/$$2",
+				"traefik.http.routers.auth.entrypoints":                              "websecure",
+				"traefik.http.routers.auth.middlewares":                              "replace-auth",
+				"traefik.http.routers.auth.rule":                                     "Host(`auth.example.com`) && PathPrefix(`/v1`)",
+				"traefik.http.routers.auth.service":                                  "auth",
+				"traefik.http.routers.auth.tls":                                      "true",
+				"traefik.http.routers.auth.tls.certresolver":                         "letsencrypt",
+				"traefik.http.services.auth.loadbalancer.server.port":                "4000",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ExportComposeFile(getConfig(), tc.opts)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			services := make([]string, 0, len(got.Services))
+			for name := range got.Services {
+				services = append(services, name)
+			}
+			sort.Strings(services)
+			if diff := cmp.Diff(tc.expectedServices, services); diff != "" {
+				t.Errorf("services mismatch (-want +got):\n%s", diff)
+			}
+
+			volumes := make([]string, 0, len(got.Volumes))
+			for name := range got.Volumes {
+				volumes = append(volumes, name)
+			}
+			sort.Strings(volumes)
+			if diff := cmp.Diff(tc.expectedVolumes, volumes); diff != "" {
+				t.Errorf("volumes mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.expectedLabels, got.Services["auth"].Labels); diff != "" {
+				t.Errorf("labels mismatch (-want +got):\n%s", diff)
+			}
+
+			b, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal compose file: %v", err)
+			}
+			if strings.Contains(string(b), "nhost.run") {
+				t.Errorf("compose file references local development hostnames")
+			}
+			if strings.Contains(string(b), "mailhog") {
+				t.Errorf("compose file references mailhog")
+			}
+
+			for _, name := range []string{"auth", "graphql", "storage", "functions"} {
+				svc, ok := got.Services[name]
+				if !ok {
+					continue
+				}
+				for k, v := range svc.Environment {
+					if strings.HasPrefix(v, "postgres://") &&
+						!strings.Contains(v, ":${POSTGRES_PASSWORD}@") {
+						t.Errorf("%s %s connects to postgres without a password: %s", name, k, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExportPgHba(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range strings.Split(strings.TrimSpace(ExportPgHba), "\n") {
+		if strings.HasPrefix(line, "host") && strings.HasSuffix(line, "trust") {
+			t.Errorf("network connections must not be trusted: %s", line)
+		}
+	}
+
+	script := ExportPostgresInitScript()
+	for _, role := range exportPostgresRoles {
+		if !strings.Contains(script, "ALTER ROLE "+role+" WITH PASSWORD :'pw';") {
+			t.Errorf("init script doesn't set the password of %s", role)
+		}
+	}
+}
+
+func TestValidatePostgresPassword(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		password string
+		valid    bool
+	}{
+		{password: "0f3a9c1d2b4e", valid: true},
+		{password: "with-dots.and_tildes~", valid: true},
+		{password: "", valid: false},
+		{password: "it's", valid: false},
+		{password: "user@host", valid: false},
+		{password: "a/b", valid: false},
+		{password: "a:b", valid: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.password, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePostgresPassword(tc.password)
+			if (err == nil) != tc.valid {
+				t.Errorf("expected valid to be %t, got %v", tc.valid, err)
+			}
+		})
+	}
+}
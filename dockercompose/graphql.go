@@ -8,7 +8,7 @@ import (
 	"golang.org/x/mod/semver"
 )
 
-func graphql(cfg *model.ConfigConfig, useTLS bool, port uint) (*Service, error) { //nolint:funlen
+func hasuraEnv(cfg *model.ConfigConfig) (map[string]string, error) {
 	envars, err := appconfig.HasuraEnv(
 		cfg,
 		"local",
@@ -24,9 +24,27 @@ func graphql(cfg *model.ConfigConfig, useTLS bool, port uint) (*Service, error)
 	for _, v := range envars {
 		env[v.Name] = v.Value
 	}
+	return env, nil
+}
+
+// JWTSecret returns the JWT secret as passed to hasura.
+func JWTSecret(cfg *model.ConfigConfig) (string, error) {
+	env, err := hasuraEnv(cfg)
+	if err != nil {
+		return "", err
+	}
+	return env["HASURA_GRAPHQL_JWT_SECRET"], nil
+}
+
+func graphql(cfg *model.ConfigConfig, useTLS bool, port uint) (*Service, error) { //nolint:funlen
+	env, err := hasuraEnv(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Service{
-		Image: fmt.Sprintf("nhost/graphql-engine:%s", *cfg.GetHasura().GetVersion()),
+		Image:  fmt.Sprintf("nhost/graphql-engine:%s", *cfg.GetHasura().GetVersion()),
+		Deploy: nil,
 		DependsOn: map[string]DependsOn{
 			"postgres": {
 				Condition: "service_healthy",
@@ -81,10 +99,10 @@ func console( //nolint:funlen
 	nhostFolder string,
 	port uint,
 ) (*Service, error) {
-	if semver.Compare(*cfg.GetHasura().GetVersion(), minimumHasuraVerson) < 0 {
+	if semver.Compare(*cfg.GetHasura().GetVersion(), MinimumHasuraVersion) < 0 {
 		return nil, fmt.Errorf( //nolint:goerr113
 			"hasura version must be at least %s",
-			minimumHasuraVerson,
+			MinimumHasuraVersion,
 		)
 	}
 
@@ -111,6 +129,7 @@ func console( //nolint:funlen
                     --api-host %s://local.hasura.nhost.run \
                     --console-hge-endpoint %s`, httpPort, scheme, URL("hasura", httpPort, useTLS)),
 		},
+		Deploy: nil,
 		DependsOn: map[string]DependsOn{
 			"graphql": {Condition: "service_healthy"},
 		},
@@ -0,0 +1,274 @@
+package dockercompose
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	postgresVersionFile = "postgres-version"
+	postgresReadyTries  = 120
+)
+
+// PostgresVersionMismatchError is returned when the data in the data folder
+// was created by a different major version of postgres than the configured one.
+type PostgresVersionMismatchError struct {
+	DataVersion string
+	Version     string
+}
+
+func (e *PostgresVersionMismatchError) Error() string {
+	return fmt.Sprintf(
+		"postgres data was created with postgres %s but postgres %s is configured",
+		e.DataVersion, e.Version,
+	)
+}
+
+// PostgresMajorVersion returns the major version of a version of the
+// nhost/postgres image, i.e. 14 for 14.6-20230406-2.
+func PostgresMajorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+// PostgresDataFolder returns the folder postgres stores its data in.
+func PostgresDataFolder(dataFolder string) string {
+	return filepath.Join(dataFolder, "db", "pgdata")
+}
+
+// PostgresDataVersion returns the major version of postgres that created the
+// data in the data folder or an empty string if there is no data.
+func PostgresDataVersion(dataFolder string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(PostgresDataFolder(dataFolder), "PG_VERSION"))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to read PG_VERSION: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// CheckPostgresData returns a *PostgresVersionMismatchError if the data in the
+// data folder can't be used by the given version of postgres.
+func CheckPostgresData(dataFolder, version string) error {
+	dataVersion, err := PostgresDataVersion(dataFolder)
+	if err != nil {
+		return err
+	}
+	if dataVersion != "" && dataVersion != PostgresMajorVersion(version) {
+		return &PostgresVersionMismatchError{DataVersion: dataVersion, Version: version}
+	}
+	return nil
+}
+
+// RecordPostgresVersion keeps track of the version of postgres used with the
+// data in the data folder so it can be used to dump it when upgrading.
+func RecordPostgresVersion(dataFolder, version string) error {
+	if err := os.WriteFile(
+		filepath.Join(dataFolder, "db", postgresVersionFile), []byte(version+"\n"), 0o644, //nolint:gomnd,gosec
+	); err != nil {
+		return fmt.Errorf("failed to record postgres version: %w", err)
+	}
+	return nil
+}
+
+// RecordedPostgresVersion returns the version recorded with
+// RecordPostgresVersion or an empty string if there is none.
+func RecordedPostgresVersion(dataFolder string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dataFolder, "db", postgresVersionFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to read recorded postgres version: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// StartPostgres starts a standalone postgres container named name using the
+// data in pgdata.
+func (d *Docker) StartPostgres(
+	ctx context.Context,
+	name, version, dataFolder, pgdata string,
+) error {
+	if err := os.MkdirAll(pgdata, 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create postgres data folder: %w", err)
+	}
+	if err := writePgHbaLocal(dataFolder); err != nil {
+		return err
+	}
+
+	absData, err := filepath.Abs(pgdata)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	absHba, err := filepath.Abs(filepath.Join(dataFolder, "db", "pg_hba_local.conf"))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		"docker", "run",
+		"-d", "--rm",
+		"--name", name,
+		"-v", fmt.Sprintf("%s:/var/lib/postgresql/data/pgdata", absData),
+		"-v", fmt.Sprintf("%s:/etc/pg_hba_local.conf", absHba),
+		"-e", "POSTGRES_USER=postgres",
+		"-e", "POSTGRES_PASSWORD=postgres",
+		"-e", "POSTGRES_DB=local",
+		"-e", "PGDATA=/var/lib/postgresql/data/pgdata",
+		fmt.Sprintf("nhost/postgres:%s", version),
+		"postgres",
+		"-c", "config_file=/etc/postgresql.conf",
+		"-c", "hba_file=/etc/pg_hba_local.conf",
+	)
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to start postgres %s: %w", version, err)
+	}
+
+	return d.waitPostgres(ctx, name)
+}
+
+func (d *Docker) waitPostgres(ctx context.Context, name string) error {
+	// we check over tcp as the temporary server used to initialize the
+	// database only listens on the unix socket
+	for i := 0; i < postgresReadyTries; i++ {
+		cmd := exec.CommandContext(
+			ctx,
+			"docker", "exec", name,
+			"pg_isready", "-U", "postgres", "-h", "127.0.0.1", "-q",
+		)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to wait for postgres: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+	return fmt.Errorf("timed out waiting for postgres to be ready") //nolint:goerr113
+}
+
+// DumpPostgres writes a dump of all the databases in the container to w. The
+// dump drops the databases and roles it creates so it can be restored on top
+// of the ones created when the new server is initialized.
+func (d *Docker) DumpPostgres(ctx context.Context, name string, w io.Writer) error {
+	cmd := exec.CommandContext(
+		ctx,
+		"docker", "exec", name,
+		"pg_dumpall", "-U", "postgres", "-h", "127.0.0.1", "--clean", "--if-exists",
+	)
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to dump databases: %w", err)
+	}
+	return nil
+}
+
+// withoutRestoreRole returns the dump without the statements that drop and
+// create the postgres role, they always fail as it is the role the dump is
+// restored with. Roles are dumped before the first \connect so data is never
+// filtered.
+func withoutRestoreRole(r io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		br := bufio.NewReader(r)
+		globals := true
+		for {
+			line, err := br.ReadString('\n')
+			if strings.HasPrefix(line, `\connect`) {
+				globals = false
+			}
+
+			stmt := strings.TrimSpace(line)
+			skip := globals &&
+				(stmt == "CREATE ROLE postgres;" || stmt == "DROP ROLE IF EXISTS postgres;")
+			if !skip && line != "" {
+				if _, err := io.WriteString(pw, line); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// RestorePostgres restores a dump created with DumpPostgres in the container.
+// It stops at the first error so a partial restore is never reported as a
+// success.
+func (d *Docker) RestorePostgres(ctx context.Context, name string, r io.Reader) error {
+	cmd := exec.CommandContext(
+		ctx,
+		"docker", "exec", "-i", name,
+		"psql", "-U", "postgres", "-h", "127.0.0.1", "-d", "postgres", "-q",
+		"-v", "ON_ERROR_STOP=1",
+	)
+	cmd.Stdin = withoutRestoreRole(r)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to restore databases: %w", err)
+	}
+	return nil
+}
+
+// StopContainer stops a container started with StartPostgres.
+func (d *Docker) StopContainer(ctx context.Context, name string) error {
+	cmd := exec.CommandContext(ctx, "docker", "stop", name)
+	if b, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop container %s: %w: %s", name, err, b)
+	}
+	return nil
+}
+
+// RemoveFolder deletes the contents of folder from inside a postgres
+// container so files created by the container's user can be removed too.
+func (d *Docker) RemoveFolder(ctx context.Context, version, folder string) error {
+	absFolder, err := filepath.Abs(folder)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		"docker", "run",
+		"--rm",
+		"-v", fmt.Sprintf("%s:/cleanup", absFolder),
+		"--entrypoint", "find",
+		fmt.Sprintf("nhost/postgres:%s", version),
+		"/cleanup", "-mindepth", "1", "-delete",
+	)
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", folder, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckPostgresData(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		pgVersion   string
+		version     string
+		expectedErr error
+	}{
+		{
+			name:        "no data",
+			pgVersion:   "",
+			version:     "15.2-20230406-1",
+			expectedErr: nil,
+		},
+		{
+			name:        "same major",
+			pgVersion:   "14",
+			version:     "14.6-20230406-2",
+			expectedErr: nil,
+		},
+		{
+			name:      "different major",
+			pgVersion: "14",
+			version:   "15.2-20230406-1",
+			expectedErr: &PostgresVersionMismatchError{
+				DataVersion: "14",
+				Version:     "15.2-20230406-1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataFolder := t.TempDir()
+			if tc.pgVersion != "" {
+				pgdata := filepath.Join(dataFolder, "db", "pgdata")
+				if err := os.MkdirAll(pgdata, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(
+					filepath.Join(pgdata, "PG_VERSION"), []byte(tc.pgVersion+"\n"), 0o600,
+				); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := CheckPostgresData(dataFolder, tc.version)
+			var mismatch *PostgresVersionMismatchError
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("got error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("expected *PostgresVersionMismatchError, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedErr, mismatch); diff != "" {
+				t.Errorf("error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordPostgresVersion(t *testing.T) {
+	t.Parallel()
+
+	dataFolder := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dataFolder, "db"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := RecordedPostgresVersion(dataFolder)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no version, got %s", got)
+	}
+
+	if err := RecordPostgresVersion(dataFolder, "14.6-20230406-2"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	got, err = RecordedPostgresVersion(dataFolder)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got != "14.6-20230406-2" {
+		t.Errorf("expected 14.6-20230406-2, got %s", got)
+	}
+}
+
+func TestWithoutRestoreRole(t *testing.T) {
+	t.Parallel()
+
+	dump := `SET default_transaction_read_only = off;
+
+DROP DATABASE IF EXISTS local;
+
+DROP ROLE IF EXISTS nhost_hasura;
+DROP ROLE IF EXISTS postgres;
+
+CREATE ROLE nhost_hasura;
+ALTER ROLE nhost_hasura WITH NOSUPERUSER;
+CREATE ROLE postgres;
+ALTER ROLE postgres WITH SUPERUSER;
+
+\connect local
+
+COPY public.notes (body) FROM stdin;
+CREATE ROLE postgres;
+\.
+`
+
+	expected := `SET default_transaction_read_only = off;
+
+DROP DATABASE IF EXISTS local;
+
+DROP ROLE IF EXISTS nhost_hasura;
+
+CREATE ROLE nhost_hasura;
+ALTER ROLE nhost_hasura WITH NOSUPERUSER;
+ALTER ROLE postgres WITH SUPERUSER;
+
+\connect local
+
+COPY public.notes (body) FROM stdin;
+CREATE ROLE postgres;
+\.
+`
+
+	b, err := io.ReadAll(withoutRestoreRole(strings.NewReader(dump)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(expected, string(b)); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -8,6 +8,21 @@ import (
 	"github.com/nhost/be/services/mimir/schema/appconfig"
 )
 
+func writePgHbaLocal(dataFolder string) error {
+	f, err := os.Create(fmt.Sprintf("%s/db/pg_hba_local.conf", dataFolder))
+	if err != nil {
+		return fmt.Errorf("failed to create pg_hba_local.conf: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(
+		"local all all trust\nhost all all all trust\n", //nolint:dupword
+	); err != nil {
+		return fmt.Errorf("failed to write to pg_hba_local.conf: %w", err)
+	}
+	return nil
+}
+
 func postgres( //nolint:funlen
 	cfg *model.ConfigConfig,
 	port uint,
@@ -17,16 +32,8 @@ func postgres( //nolint:funlen
 		return nil, fmt.Errorf("failed to create postgres data folder: %w", err)
 	}
 
-	f, err := os.Create(fmt.Sprintf("%s/db/pg_hba_local.conf", dataFolder))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create pg_hba_local.conf: %w", err)
-	}
-	defer f.Close()
-
-	if _, err := f.WriteString(
-		"local all all trust\nhost all all all trust\n", //nolint:dupword
-	); err != nil {
-		return nil, fmt.Errorf("failed to write to pg_hba_local.conf: %w", err)
+	if err := writePgHbaLocal(dataFolder); err != nil {
+		return nil, err
 	}
 
 	envars, err := appconfig.PostgresEnv(
@@ -45,6 +52,7 @@ func postgres( //nolint:funlen
 
 	return &Service{
 		Image:      fmt.Sprintf("nhost/postgres:%s", *cfg.GetPostgres().GetVersion()),
+		Deploy:     nil,
 		DependsOn:  nil,
 		EntryPoint: nil,
 		Command: []string{
@@ -0,0 +1,81 @@
+package dockercompose
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nhost/be/services/mimir/model"
+)
+
+type Deploy struct {
+	Replicas  *uint8     `yaml:"replicas,omitempty"`
+	Resources *Resources `yaml:"resources,omitempty"`
+}
+
+type Resources struct {
+	Limits ResourcesLimits `yaml:"limits"`
+}
+
+type ResourcesLimits struct {
+	Cpus   string `yaml:"cpus"`
+	Memory string `yaml:"memory"`
+}
+
+func deploy(resources *model.ConfigResources, scalable bool) *Deploy {
+	compute := resources.GetCompute()
+	if compute == nil && resources.GetReplicas() <= 1 {
+		return nil
+	}
+
+	d := &Deploy{
+		Replicas:  nil,
+		Resources: nil,
+	}
+	if compute != nil {
+		d.Resources = &Resources{
+			Limits: ResourcesLimits{
+				Cpus:   strconv.FormatFloat(float64(compute.Cpu)/1000, 'f', -1, 64), //nolint:gomnd
+				Memory: fmt.Sprintf("%dM", compute.Memory),
+			},
+		}
+	}
+	if scalable && resources.GetReplicas() > 1 {
+		d.Replicas = ptr(resources.GetReplicas())
+	}
+	return d
+}
+
+// ApplyResources limits the cpu and memory of the services to the resources
+// configured for the cloud and scales them to the configured replicas.
+// Replicas sit behind traefik, which balances requests among them. Postgres
+// and services exposing a port on the host aren't scaled. It returns the
+// names of the services that were limited.
+func ApplyResources(c *ComposeFile, cfg *model.ConfigConfig) []string {
+	resources := []struct {
+		service   string
+		resources *model.ConfigResources
+		scalable  bool
+	}{
+		{service: "auth", resources: cfg.GetAuth().GetResources(), scalable: true},
+		{service: "graphql", resources: cfg.GetHasura().GetResources(), scalable: true},
+		{service: "postgres", resources: cfg.GetPostgres().GetResources(), scalable: false},
+		{service: "storage", resources: cfg.GetStorage().GetResources(), scalable: true},
+	}
+
+	var applied []string
+	for _, r := range resources {
+		svc, ok := c.Services[r.service]
+		if !ok {
+			continue
+		}
+
+		d := deploy(r.resources, r.scalable && len(svc.Ports) == 0)
+		if d == nil {
+			continue
+		}
+		svc.Deploy = d
+		applied = append(applied, r.service)
+	}
+
+	return applied
+}
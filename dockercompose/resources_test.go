@@ -0,0 +1,108 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/be/services/mimir/model"
+)
+
+func TestApplyResources(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		cfg             func() *model.ConfigConfig
+		services        map[string]*Service
+		expectedApplied []string
+		expected        map[string]*Deploy
+	}{
+		{
+			name: "limits and replicas",
+			cfg:  getConfig,
+			services: map[string]*Service{
+				"auth":     {}, //nolint:exhaustruct
+				"graphql":  {}, //nolint:exhaustruct
+				"postgres": {}, //nolint:exhaustruct
+				"storage":  {}, //nolint:exhaustruct
+				"mailhog":  {}, //nolint:exhaustruct
+			},
+			expectedApplied: []string{"auth", "graphql", "postgres", "storage"},
+			expected: map[string]*Deploy{
+				"auth": {
+					Replicas:  ptr(uint8(3)),
+					Resources: &Resources{Limits: ResourcesLimits{Cpus: "1", Memory: "300M"}},
+				},
+				"graphql": {
+					Replicas:  ptr(uint8(3)),
+					Resources: &Resources{Limits: ResourcesLimits{Cpus: "1", Memory: "700M"}},
+				},
+				"postgres": {
+					Replicas:  nil,
+					Resources: &Resources{Limits: ResourcesLimits{Cpus: "2", Memory: "500M"}},
+				},
+				"storage": {
+					Replicas:  nil,
+					Resources: &Resources{Limits: ResourcesLimits{Cpus: "0.5", Memory: "50M"}},
+				},
+				"mailhog": nil,
+			},
+		},
+		{
+			name: "exposed services aren't scaled",
+			cfg:  getConfig,
+			services: map[string]*Service{
+				"auth": {Ports: ports(4000, 4000)}, //nolint:exhaustruct
+			},
+			expectedApplied: []string{"auth"},
+			expected: map[string]*Deploy{
+				"auth": {
+					Replicas:  nil,
+					Resources: &Resources{Limits: ResourcesLimits{Cpus: "1", Memory: "300M"}},
+				},
+			},
+		},
+		{
+			name: "no resources",
+			cfg: func() *model.ConfigConfig {
+				cfg := getConfig()
+				cfg.Auth.Resources = nil
+				cfg.Hasura.Resources = nil
+				cfg.Postgres.Resources = nil
+				cfg.Storage.Resources = nil
+				return cfg
+			},
+			services: map[string]*Service{
+				"auth":    {}, //nolint:exhaustruct
+				"graphql": {}, //nolint:exhaustruct
+			},
+			expectedApplied: nil,
+			expected: map[string]*Deploy{
+				"auth":    nil,
+				"graphql": nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &ComposeFile{Version: "3.8", Services: tc.services, Volumes: nil}
+			applied := ApplyResources(c, tc.cfg())
+
+			if diff := cmp.Diff(tc.expectedApplied, applied); diff != "" {
+				t.Errorf("applied mismatch (-want +got):\n%s", diff)
+			}
+
+			got := make(map[string]*Deploy, len(c.Services))
+			for name, svc := range c.Services {
+				got[name] = svc.Deploy
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("deploy mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package dockercompose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func serviceNames(services map[string]*Service) []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkServices(c *ComposeFile, names []string) error {
+	for _, name := range names {
+		if _, ok := c.Services[name]; !ok {
+			return fmt.Errorf( //nolint:goerr113
+				"unknown service %s, available services: %s",
+				name, strings.Join(serviceNames(c.Services), ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// SelectServices removes from the compose file all services except the ones
+// in services, all if empty, and the services they depend on. Services in
+// without are removed as well, it is an error if any of the remaining services
+// depends on them. Traefik is kept if any of the remaining services is exposed
+// through it.
+func SelectServices(c *ComposeFile, services, without []string) error {
+	if err := checkServices(c, services); err != nil {
+		return err
+	}
+	if err := checkServices(c, without); err != nil {
+		return err
+	}
+
+	if len(services) == 0 {
+		services = serviceNames(c.Services)
+	}
+
+	excluded := make(map[string]bool, len(without))
+	for _, name := range without {
+		excluded[name] = true
+	}
+
+	selected := make(map[string]bool, len(c.Services))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if selected[name] {
+			return nil
+		}
+		selected[name] = true
+
+		deps := make([]string, 0, len(c.Services[name].DependsOn))
+		for dep := range c.Services[name].DependsOn {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+
+		for _, dep := range deps {
+			if excluded[dep] {
+				return fmt.Errorf("can't remove %s, %s depends on it", dep, name) //nolint:goerr113
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, name := range services {
+		if excluded[name] {
+			continue
+		}
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := c.Services["traefik"]; ok && !excluded["traefik"] {
+		for name := range selected {
+			if len(c.Services[name].Labels) > 0 {
+				selected["traefik"] = true
+				break
+			}
+		}
+	}
+
+	for name := range c.Services {
+		if !selected[name] {
+			delete(c.Services, name)
+		}
+	}
+
+	return nil
+}
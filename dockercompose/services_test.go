@@ -0,0 +1,121 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testComposeFile() *ComposeFile {
+	dependsOn := func(names ...string) map[string]DependsOn {
+		deps := make(map[string]DependsOn, len(names))
+		for _, name := range names {
+			deps[name] = DependsOn{Condition: "service_healthy"}
+		}
+		return deps
+	}
+	exposed := map[string]string{"traefik.enable": "true"}
+
+	//nolint:exhaustruct
+	return &ComposeFile{
+		Version: "3.8",
+		Services: map[string]*Service{
+			"auth":      {DependsOn: dependsOn("graphql", "postgres"), Labels: exposed},
+			"console":   {DependsOn: dependsOn("graphql"), Labels: exposed},
+			"dashboard": {Labels: exposed},
+			"functions": {Labels: exposed},
+			"graphql":   {DependsOn: dependsOn("postgres", "functions"), Labels: exposed},
+			"mailhog":   {Labels: exposed},
+			"minio":     {},
+			"postgres":  {},
+			"storage":   {DependsOn: dependsOn("minio", "graphql", "postgres"), Labels: exposed},
+			"traefik":   {},
+		},
+	}
+}
+
+func TestSelectServices(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		services    []string
+		without     []string
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:     "all",
+			services: nil,
+			without:  nil,
+			expected: []string{
+				"auth", "console", "dashboard", "functions", "graphql",
+				"mailhog", "minio", "postgres", "storage", "traefik",
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "dependencies are included",
+			services:    []string{"postgres", "graphql"},
+			without:     nil,
+			expected:    []string{"functions", "graphql", "postgres", "traefik"},
+			expectedErr: nil,
+		},
+		{
+			name:        "traefik is skipped if nothing is exposed",
+			services:    []string{"postgres"},
+			without:     nil,
+			expected:    []string{"postgres"},
+			expectedErr: nil,
+		},
+		{
+			name:     "without",
+			services: nil,
+			without:  []string{"dashboard", "mailhog"},
+			expected: []string{
+				"auth", "console", "functions", "graphql", "minio", "postgres", "storage", "traefik",
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "without a dependency",
+			services:    []string{"auth"},
+			without:     []string{"postgres"},
+			expected:    nil,
+			expectedErr: errors.New("can't remove postgres, graphql depends on it"), //nolint:goerr113
+		},
+		{
+			name:     "unknown service",
+			services: []string{"hasura"},
+			without:  nil,
+			expected: nil,
+			expectedErr: errors.New( //nolint:goerr113
+				"unknown service hasura, available services: auth, console, dashboard, functions, graphql, mailhog, minio, postgres, storage, traefik", //nolint:lll
+			),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testComposeFile()
+			err := SelectServices(c, tc.services, tc.without)
+			if tc.expectedErr != nil {
+				if err == nil || err.Error() != tc.expectedErr.Error() {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expected, serviceNames(c.Services)); diff != "" {
+				t.Errorf("services mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package dockercompose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type ServiceStatus struct {
+	Name     string `json:"Name"`
+	Service  string `json:"Service"`
+	State    string `json:"State"`
+	Health   string `json:"Health"`
+	ExitCode int    `json:"ExitCode"`
+}
+
+// Failed returns true if the container exited with an error or its
+// healthcheck is failing.
+func (s ServiceStatus) Failed() bool {
+	return (s.State == "exited" && s.ExitCode != 0) || s.Health == "unhealthy"
+}
+
+func parseStatus(b []byte) ([]ServiceStatus, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	// older versions of docker compose output a json array, newer ones
+	// a json object per line
+	if b[0] == '[' {
+		var statuses []ServiceStatus
+		if err := json.Unmarshal(b, &statuses); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status: %w", err)
+		}
+		return statuses, nil
+	}
+
+	var statuses []ServiceStatus
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var status ServiceStatus
+		if err := json.Unmarshal(line, &status); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status: %w", err)
+	}
+	return statuses, nil
+}
+
+// Status returns the status of all the containers of the project, including
+// the ones that exited.
+func (dc *DockerCompose) Status(ctx context.Context) ([]ServiceStatus, error) {
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		"docker", "compose",
+		"--project-directory", dc.workingDir,
+		"-f", dc.filepath,
+		"-p", dc.projectName,
+		"ps", "--all", "--format", "json",
+	)
+
+	b, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status from docker compose: %w", err)
+	}
+
+	return parseStatus(b)
+}
+
+// ServiceLogs returns the last tail lines of logs of a service.
+func (dc *DockerCompose) ServiceLogs(ctx context.Context, service string, tail int) (string, error) {
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		"docker", "compose",
+		"--project-directory", dc.workingDir,
+		"-f", dc.filepath,
+		"-p", dc.projectName,
+		"logs", "--no-color", "--tail", strconv.Itoa(tail),
+		service,
+	)
+
+	b, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs from docker compose: %w", err)
+	}
+	return string(b), nil
+}
+
+type healthLog struct {
+	Log []struct {
+		ExitCode int    `json:"ExitCode"`
+		Output   string `json:"Output"`
+	} `json:"Log"`
+}
+
+// HealthcheckOutput returns the output of the last healthcheck run in the
+// container.
+func (dc *DockerCompose) HealthcheckOutput(ctx context.Context, container string) (string, error) {
+	cmd := exec.CommandContext(
+		ctx,
+		"docker", "inspect",
+		"--format", "{{json .State.Health}}",
+		container,
+	)
+
+	b, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	var health *healthLog
+	if err := json.Unmarshal(bytes.TrimSpace(b), &health); err != nil {
+		return "", fmt.Errorf("failed to unmarshal health: %w", err)
+	}
+	if health == nil || len(health.Log) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(health.Log[len(health.Log)-1].Output), nil
+}
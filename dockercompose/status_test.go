@@ -0,0 +1,47 @@
+package dockercompose //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStatus(t *testing.T) {
+	t.Parallel()
+
+	expected := []ServiceStatus{
+		{Name: "local-auth-1", Service: "auth", State: "running", Health: "unhealthy", ExitCode: 0},
+		{Name: "local-minio-1", Service: "minio", State: "exited", Health: "", ExitCode: 1},
+	}
+
+	cases := []struct {
+		name   string
+		output string
+	}{
+		{
+			name:   "json array",
+			output: `[{"Name":"local-auth-1","Service":"auth","State":"running","Health":"unhealthy","ExitCode":0},{"Name":"local-minio-1","Service":"minio","State":"exited","Health":"","ExitCode":1}]`, //nolint:lll
+		},
+		{
+			name: "json lines",
+			output: `{"Name":"local-auth-1","Service":"auth","State":"running","Health":"unhealthy","ExitCode":0}
+{"Name":"local-minio-1","Service":"minio","State":"exited","Health":"","ExitCode":1}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseStatus([]byte(tc.output))
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("status mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
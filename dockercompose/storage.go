@@ -35,7 +35,8 @@ func storage(
 	}
 
 	return &Service{
-		Image: fmt.Sprintf("nhost/hasura-storage:%s", *cfg.GetStorage().GetVersion()),
+		Image:  fmt.Sprintf("nhost/hasura-storage:%s", *cfg.GetStorage().GetVersion()),
+		Deploy: nil,
 		DependsOn: map[string]DependsOn{
 			"minio": {
 				Condition: "service_started",
@@ -0,0 +1,334 @@
+/*
+This package retrieves GraphQL schemas via introspection and converts them
+between their introspection and SDL representations.
+*/
+package gqlschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nhost/cli/hasura"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type Introspection struct {
+	Schema IntrospectionSchema `json:"__schema"`
+}
+
+type IntrospectionSchema struct {
+	QueryType        *TypeName   `json:"queryType"`
+	MutationType     *TypeName   `json:"mutationType"`
+	SubscriptionType *TypeName   `json:"subscriptionType"`
+	Types            []FullType  `json:"types"`
+	Directives       []Directive `json:"directives"`
+}
+
+type TypeName struct {
+	Name string `json:"name"`
+}
+
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   *string  `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+type InputValue struct {
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type Field struct {
+	Name              string       `json:"name"`
+	Description       *string      `json:"description"`
+	Args              []InputValue `json:"args"`
+	Type              TypeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated"`
+	DeprecationReason *string      `json:"deprecationReason"`
+}
+
+type EnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type FullType struct {
+	Kind          string       `json:"kind"`
+	Name          string       `json:"name"`
+	Description   *string      `json:"description"`
+	Fields        []Field      `json:"fields"`
+	InputFields   []InputValue `json:"inputFields"`
+	Interfaces    []TypeRef    `json:"interfaces"`
+	EnumValues    []EnumValue  `json:"enumValues"`
+	PossibleTypes []TypeRef    `json:"possibleTypes"`
+}
+
+type Directive struct {
+	Name        string       `json:"name"`
+	Description *string      `json:"description"`
+	Locations   []string     `json:"locations"`
+	Args        []InputValue `json:"args"`
+}
+
+// Introspect runs the introspection query against the endpoint the client
+// points to. The schema returned depends on the role in the client's headers.
+func Introspect(ctx context.Context, client *hasura.Client) (*Introspection, error) {
+	var introspection Introspection
+	if err := client.Query(ctx, IntrospectionQuery, nil, &introspection); err != nil {
+		return nil, fmt.Errorf("failed to introspect schema: %w", err)
+	}
+	return &introspection, nil
+}
+
+func ParseIntrospection(b []byte) (*Introspection, error) {
+	var introspection Introspection
+	if err := json.Unmarshal(b, &introspection); err != nil {
+		return nil, fmt.Errorf("failed to parse introspection: %w", err)
+	}
+
+	// accept the full response as well as only its data
+	if introspection.Schema.QueryType == nil {
+		var resp struct {
+			Data Introspection `json:"data"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse introspection: %w", err)
+		}
+		introspection = resp.Data
+	}
+
+	if introspection.Schema.QueryType == nil {
+		return nil, fmt.Errorf("introspection is missing the query type") //nolint:goerr113
+	}
+
+	return &introspection, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (t TypeRef) astType() *ast.Type {
+	switch t.Kind {
+	case "NON_NULL":
+		typ := t.OfType.astType()
+		typ.NonNull = true
+		return typ
+	case "LIST":
+		return ast.ListType(t.OfType.astType(), nil)
+	default:
+		return ast.NamedType(deref(t.Name), nil)
+	}
+}
+
+func argumentDefinitions(values []InputValue) ast.ArgumentDefinitionList {
+	args := make(ast.ArgumentDefinitionList, len(values))
+	for i, v := range values {
+		args[i] = &ast.ArgumentDefinition{ //nolint:exhaustruct
+			Description:  deref(v.Description),
+			Name:         v.Name,
+			DefaultValue: defaultValue(v.DefaultValue),
+			Type:         v.Type.astType(),
+		}
+	}
+	return args
+}
+
+// defaultValue returns the default value as returned by the introspection,
+// which is already formatted as a GraphQL literal.
+func defaultValue(v *string) *ast.Value {
+	if v == nil {
+		return nil
+	}
+	return &ast.Value{Kind: ast.EnumValue, Raw: *v} //nolint:exhaustruct
+}
+
+func deprecated(isDeprecated bool, reason *string) ast.DirectiveList {
+	if !isDeprecated {
+		return nil
+	}
+
+	directive := &ast.Directive{Name: "deprecated"} //nolint:exhaustruct
+	if reason != nil {
+		directive.Arguments = ast.ArgumentList{
+			{
+				Name:     "reason",
+				Value:    &ast.Value{Kind: ast.StringValue, Raw: *reason}, //nolint:exhaustruct
+				Position: nil,
+			},
+		}
+	}
+	return ast.DirectiveList{directive}
+}
+
+func (t FullType) definition() *ast.Definition {
+	def := &ast.Definition{ //nolint:exhaustruct
+		Kind:        ast.DefinitionKind(t.Kind),
+		Description: deref(t.Description),
+		Name:        t.Name,
+		BuiltIn:     isBuiltInType(t.Name),
+	}
+
+	for _, f := range t.Fields {
+		def.Fields = append(def.Fields, &ast.FieldDefinition{ //nolint:exhaustruct
+			Description: deref(f.Description),
+			Name:        f.Name,
+			Arguments:   argumentDefinitions(f.Args),
+			Type:        f.Type.astType(),
+			Directives:  deprecated(f.IsDeprecated, f.DeprecationReason),
+		})
+	}
+
+	for _, f := range t.InputFields {
+		def.Fields = append(def.Fields, &ast.FieldDefinition{ //nolint:exhaustruct
+			Description:  deref(f.Description),
+			Name:         f.Name,
+			DefaultValue: defaultValue(f.DefaultValue),
+			Type:         f.Type.astType(),
+		})
+	}
+
+	for _, i := range t.Interfaces {
+		def.Interfaces = append(def.Interfaces, deref(i.Name))
+	}
+
+	if t.Kind == string(ast.Union) {
+		for _, p := range t.PossibleTypes {
+			def.Types = append(def.Types, deref(p.Name))
+		}
+	}
+
+	for _, v := range t.EnumValues {
+		def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{ //nolint:exhaustruct
+			Description: deref(v.Description),
+			Name:        v.Name,
+			Directives:  deprecated(v.IsDeprecated, v.DeprecationReason),
+		})
+	}
+
+	return def
+}
+
+// AST converts the introspection into a schema.
+func (i *Introspection) AST() *ast.Schema {
+	schema := newSchema()
+
+	for _, t := range i.Schema.Types {
+		schema.Types[t.Name] = t.definition()
+	}
+
+	for _, d := range i.Schema.Directives {
+		locations := make([]ast.DirectiveLocation, len(d.Locations))
+		for i, l := range d.Locations {
+			locations[i] = ast.DirectiveLocation(l)
+		}
+		schema.Directives[d.Name] = &ast.DirectiveDefinition{ //nolint:exhaustruct
+			Description: deref(d.Description),
+			Name:        d.Name,
+			Arguments:   argumentDefinitions(d.Args),
+			Locations:   locations,
+			Position:    builtInPosition(isBuiltInDirective(d.Name)),
+		}
+	}
+
+	if i.Schema.QueryType != nil {
+		schema.Query = schema.Types[i.Schema.QueryType.Name]
+	}
+	if i.Schema.MutationType != nil {
+		schema.Mutation = schema.Types[i.Schema.MutationType.Name]
+	}
+	if i.Schema.SubscriptionType != nil {
+		schema.Subscription = schema.Types[i.Schema.SubscriptionType.Name]
+	}
+
+	addPossibleTypes(schema)
+
+	return schema
+}
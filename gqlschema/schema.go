@@ -0,0 +1,208 @@
+package gqlschema
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func isBuiltInType(name string) bool {
+	switch name {
+	case "String", "Int", "Float", "Boolean", "ID":
+		return true
+	default:
+		return len(name) > 2 && name[:2] == "__"
+	}
+}
+
+func isBuiltInDirective(name string) bool {
+	switch name {
+	case "include", "skip", "deprecated", "specifiedBy":
+		return true
+	default:
+		return false
+	}
+}
+
+func builtInPosition(builtIn bool) *ast.Position {
+	return &ast.Position{ //nolint:exhaustruct
+		Src: &ast.Source{Name: "", Input: "", BuiltIn: builtIn},
+	}
+}
+
+func newSchema() *ast.Schema {
+	return &ast.Schema{ //nolint:exhaustruct
+		Types:         make(map[string]*ast.Definition),
+		Directives:    make(map[string]*ast.DirectiveDefinition),
+		PossibleTypes: make(map[string][]*ast.Definition),
+		Implements:    make(map[string][]*ast.Definition),
+	}
+}
+
+func addPossibleTypes(schema *ast.Schema) {
+	for _, def := range schema.Types {
+		switch def.Kind { //nolint:exhaustive
+		case ast.Object:
+			for _, name := range def.Interfaces {
+				if iface, ok := schema.Types[name]; ok {
+					schema.AddPossibleType(name, def)
+					schema.AddImplements(def.Name, iface)
+				}
+			}
+		case ast.Union:
+			for _, name := range def.Types {
+				if t, ok := schema.Types[name]; ok {
+					schema.AddPossibleType(def.Name, t)
+					schema.AddImplements(name, def)
+				}
+			}
+		}
+	}
+}
+
+// FromSDL builds a schema from its SDL representation. The schema isn't
+// validated beyond what's needed to resolve its root types.
+func FromSDL(name, sdl string) (*ast.Schema, error) {
+	doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: sdl, BuiltIn: false})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	schema := newSchema()
+	for _, scalar := range []string{"String", "Int", "Float", "Boolean", "ID"} {
+		schema.Types[scalar] = &ast.Definition{ //nolint:exhaustruct
+			Kind:    ast.Scalar,
+			Name:    scalar,
+			BuiltIn: true,
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		schema.Types[def.Name] = def
+	}
+	for _, ext := range doc.Extensions {
+		def, ok := schema.Types[ext.Name]
+		if !ok {
+			return nil, fmt.Errorf("extension of unknown type %s", ext.Name) //nolint:goerr113
+		}
+		def.Fields = append(def.Fields, ext.Fields...)
+		def.EnumValues = append(def.EnumValues, ext.EnumValues...)
+		def.Interfaces = append(def.Interfaces, ext.Interfaces...)
+		def.Types = append(def.Types, ext.Types...)
+	}
+	for _, d := range doc.Directives {
+		schema.Directives[d.Name] = d
+	}
+
+	roots := map[ast.Operation]string{
+		ast.Query:        "Query",
+		ast.Mutation:     "Mutation",
+		ast.Subscription: "Subscription",
+	}
+	for _, sd := range append(doc.Schema, doc.SchemaExtension...) {
+		for _, ot := range sd.OperationTypes {
+			roots[ot.Operation] = ot.Type
+		}
+	}
+	schema.Query = schema.Types[roots[ast.Query]]
+	schema.Mutation = schema.Types[roots[ast.Mutation]]
+	schema.Subscription = schema.Types[roots[ast.Subscription]]
+
+	if schema.Query == nil {
+		return nil, fmt.Errorf("schema is missing the query type") //nolint:goerr113
+	}
+
+	addPossibleTypes(schema)
+
+	return schema, nil
+}
+
+// Load reads a schema from a file either in SDL format, if its extension is
+// .graphql or .gql, or as the JSON result of an introspection query.
+func Load(path string) (*ast.Schema, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".graphql", ".gql":
+		return FromSDL(path, string(b))
+	default:
+		introspection, err := ParseIntrospection(b)
+		if err != nil {
+			return nil, err
+		}
+		return introspection.AST(), nil
+	}
+}
+
+// description escapes s so the formatter writes it as a valid block string,
+// which can't contain """ nor end with a quote.
+func description(s string) string {
+	s = strings.ReplaceAll(s, `"""`, `\"""`)
+	if strings.HasSuffix(s, `"`) {
+		// trailing empty lines are removed from block strings when parsed
+		s += "\n"
+	}
+	return s
+}
+
+// withEscapedDescriptions returns a copy of the schema with its descriptions
+// escaped as expected by the formatter.
+func withEscapedDescriptions(schema *ast.Schema) *ast.Schema {
+	args := func(list ast.ArgumentDefinitionList) ast.ArgumentDefinitionList {
+		res := make(ast.ArgumentDefinitionList, len(list))
+		for i, a := range list {
+			c := *a
+			c.Description = description(a.Description)
+			res[i] = &c
+		}
+		return res
+	}
+
+	s := *schema
+	s.Types = make(map[string]*ast.Definition, len(schema.Types))
+	for name, def := range schema.Types {
+		d := *def
+		d.Description = description(def.Description)
+		d.Fields = make(ast.FieldList, len(def.Fields))
+		for i, f := range def.Fields {
+			c := *f
+			c.Description = description(f.Description)
+			c.Arguments = args(f.Arguments)
+			d.Fields[i] = &c
+		}
+		d.EnumValues = make(ast.EnumValueList, len(def.EnumValues))
+		for i, v := range def.EnumValues {
+			c := *v
+			c.Description = description(v.Description)
+			d.EnumValues[i] = &c
+		}
+		s.Types[name] = &d
+	}
+
+	s.Directives = make(map[string]*ast.DirectiveDefinition, len(schema.Directives))
+	for name, dir := range schema.Directives {
+		d := *dir
+		d.Description = description(dir.Description)
+		d.Arguments = args(dir.Arguments)
+		s.Directives[name] = &d
+	}
+
+	return &s
+}
+
+func SDL(schema *ast.Schema) string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatSchema(
+		withEscapedDescriptions(schema),
+	)
+	return buf.String()
+}
@@ -0,0 +1,46 @@
+package gqlschema_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/gqlschema"
+)
+
+func TestSDL(t *testing.T) {
+	t.Parallel()
+
+	expected, err := os.ReadFile("testdata/schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := gqlschema.Load("testdata/introspection.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := gqlschema.SDL(schema)
+	if diff := cmp.Diff(string(expected), got); diff != "" {
+		t.Error(diff)
+	}
+
+	// the SDL must be parseable back into the same schema
+	schema, err = gqlschema.FromSDL("schema.graphql", got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(got, gqlschema.SDL(schema)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseIntrospectionMissingQueryType(t *testing.T) {
+	t.Parallel()
+
+	if _, err := gqlschema.ParseIntrospection([]byte(`{"data": {}}`)); err == nil {
+		t.Error("expected an error parsing an introspection without query type")
+	}
+}
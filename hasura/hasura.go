@@ -0,0 +1,151 @@
+/*
+This package provides a minimal client to send GraphQL operations to hasura.
+*/
+package hasura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+type Error struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []Error         `json:"errors,omitempty"`
+}
+
+type ResponseError struct {
+	Errors []Error
+}
+
+func (e *ResponseError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+type Client struct {
+	url     string
+	headers http.Header
+	client  *http.Client
+}
+
+func NewClient(url string, headers http.Header) *Client {
+	return &Client{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{}, //nolint:exhaustruct
+	}
+}
+
+func AdminHeaders(adminSecret string) http.Header {
+	return http.Header{
+		"X-Hasura-Admin-Secret": []string{adminSecret},
+	}
+}
+
+// SessionHeaders returns the headers to impersonate a session using the admin
+// secret. Session variables must include the x-hasura- prefix.
+func SessionHeaders(adminSecret, role string, sessionVariables map[string]string) http.Header {
+	headers := AdminHeaders(adminSecret)
+	if role != "" {
+		headers.Set("X-Hasura-Role", role)
+	}
+	for k, v := range sessionVariables {
+		headers.Set(k, v)
+	}
+	return headers
+}
+
+func BearerHeaders(token string) http.Header {
+	return http.Header{
+		"Authorization": []string{"Bearer " + token},
+	}
+}
+
+// Do sends the request and returns the response as returned by hasura,
+// GraphQL errors are not considered errors by this function.
+func (c *Client) Do(ctx context.Context, request Request) (*Response, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, fmt.Errorf( //nolint:goerr113
+			"unexpected response, status code: %d, body: %s", resp.StatusCode, string(b),
+		)
+	}
+
+	return &response, nil
+}
+
+// Query sends the request and unmarshals the data into v. GraphQL errors are
+// returned as *ResponseError.
+func (c *Client) Query(
+	ctx context.Context,
+	query string,
+	variables map[string]any,
+	v any,
+) error {
+	resp, err := c.Do(ctx, Request{
+		Query:         query,
+		Variables:     variables,
+		OperationName: "",
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		return &ResponseError{Errors: resp.Errors}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,526 @@
+/*
+This package mints and verifies hasura JWTs using the project's JWT secret.
+Only HMAC (HS256, HS384, HS512) and RSA (RS256, RS384, RS512) are supported.
+*/
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "crypto/sha256" // register SHA256
+	_ "crypto/sha512" // register SHA384 and SHA512
+
+	"github.com/nhost/be/services/mimir/model"
+)
+
+const (
+	DefaultClaimsNamespace  = "https://hasura.io/jwt/claims"
+	claimsFormatStringified = "stringified_json"
+	hasuraClaimPrefix       = "x-hasura-"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrExpired              = errors.New("token is expired")
+)
+
+func unptr[T any](t *T) T { //nolint:ireturn
+	if t == nil {
+		return *new(T)
+	}
+	return *t
+}
+
+func hashFor(alg string) (crypto.Hash, error) {
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, nil
+	case "384":
+		return crypto.SHA384, nil
+	case "512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+}
+
+func algorithm(secret *model.ConfigJWTSecret) (string, error) {
+	if secret.GetJwkUrl() != nil && unptr(secret.GetKey()) == "" {
+		return "", fmt.Errorf("%w: jwk_url secrets can't be used to sign tokens", ErrUnsupportedAlgorithm)
+	}
+
+	alg := unptr(secret.GetType())
+	if alg == "" {
+		alg = "HS256"
+	}
+
+	if len(alg) != 5 || (!strings.HasPrefix(alg, "HS") && !strings.HasPrefix(alg, "RS")) { //nolint:gomnd
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+
+	return alg, nil
+}
+
+// HasuraClaims places the hasura claims in the token claims where hasura
+// expects them according to the secret's claims namespace and format.
+func HasuraClaims(
+	secret *model.ConfigJWTSecret,
+	claims map[string]any,
+	hasuraClaims map[string]any,
+) error {
+	var value any = hasuraClaims
+	if unptr(secret.GetClaimsFormat()) == claimsFormatStringified {
+		b, err := json.Marshal(hasuraClaims)
+		if err != nil {
+			return fmt.Errorf("failed to marshal hasura claims: %w", err)
+		}
+		value = string(b)
+	}
+
+	if path := unptr(secret.GetClaimsNamespacePath()); path != "" {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+		if path == "" {
+			for k, v := range hasuraClaims {
+				claims[k] = v
+			}
+			return nil
+		}
+
+		parts := strings.Split(path, ".")
+		cur := claims
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := cur[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				cur[part] = next
+			}
+			cur = next
+		}
+		cur[parts[len(parts)-1]] = value
+		return nil
+	}
+
+	namespace := unptr(secret.GetClaimsNamespace())
+	if namespace == "" {
+		namespace = DefaultClaimsNamespace
+	}
+	claims[namespace] = value
+
+	return nil
+}
+
+// StandardClaims returns the registered claims for a token issued now.
+func StandardClaims(
+	secret *model.ConfigJWTSecret,
+	subject string,
+	expiresIn time.Duration,
+) map[string]any {
+	now := time.Now()
+	claims := map[string]any{
+		"iat": now.Unix(),
+		"exp": now.Add(expiresIn).Unix(),
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	if iss := unptr(secret.GetIssuer()); iss != "" {
+		claims["iss"] = iss
+	}
+	if aud := unptr(secret.GetAudience()); aud != "" {
+		claims["aud"] = aud
+	}
+	return claims
+}
+
+func encodeSegment(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal segment: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func parseRSAPrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM private key") //nolint:goerr113
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key") //nolint:goerr113
+	}
+	return rsaKey, nil
+}
+
+func parseRSAPublicKey(b []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM public key") //nolint:goerr113
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an RSA key") //nolint:goerr113
+	}
+	return rsaKey, nil
+}
+
+// Sign signs the claims with the secret. For RSA secrets the key in the
+// secret is the public key so the private key needs to be passed explicitly.
+func Sign(
+	secret *model.ConfigJWTSecret,
+	claims map[string]any,
+	privateKey []byte,
+) (string, error) {
+	alg, err := algorithm(secret)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := hashFor(alg)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := encodeSegment(map[string]string{"alg": alg, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + payload
+
+	var signature []byte
+	switch alg[:2] {
+	case "HS":
+		mac := hmac.New(hash.New, []byte(unptr(secret.GetKey())))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case "RS":
+		if len(privateKey) == 0 {
+			return "", fmt.Errorf("a private key is required to sign %s tokens", alg) //nolint:goerr113
+		}
+		key, err := parseRSAPrivateKey(privateKey)
+		if err != nil {
+			return "", err
+		}
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		signature, err = rsa.SignPKCS1v15(nil, key, hash, h.Sum(nil))
+		if err != nil {
+			return "", fmt.Errorf("failed to sign token: %w", err)
+		}
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+type Token struct {
+	Header    map[string]any
+	Claims    map[string]any
+	signature []byte
+	signed    string
+}
+
+// Parse decodes the token without verifying it.
+func Parse(token string) (*Token, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 { //nolint:gomnd
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrInvalidToken, len(parts))
+	}
+
+	t := &Token{
+		Header:    map[string]any{},
+		Claims:    map[string]any{},
+		signature: nil,
+		signed:    parts[0] + "." + parts[1],
+	}
+
+	for i, v := range []*map[string]any{&t.Header, &t.Claims} {
+		b, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to decode segment: %s", ErrInvalidToken, err.Error())
+		}
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal segment: %s", ErrInvalidToken, err.Error())
+		}
+	}
+
+	var err error
+	t.signature, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode signature: %s", ErrInvalidToken, err.Error())
+	}
+
+	return t, nil
+}
+
+// Verify checks the signature of the token against the secret and that the
+// token hasn't expired.
+func (t *Token) Verify(secret *model.ConfigJWTSecret) error {
+	alg, err := algorithm(secret)
+	if err != nil {
+		return err
+	}
+
+	if h, _ := t.Header["alg"].(string); h != alg {
+		return fmt.Errorf( //nolint:goerr113
+			"%w: token algorithm %s doesn't match secret's %s", ErrInvalidSignature, h, alg,
+		)
+	}
+
+	hash, err := hashFor(alg)
+	if err != nil {
+		return err
+	}
+
+	switch alg[:2] {
+	case "HS":
+		mac := hmac.New(hash.New, []byte(unptr(secret.GetKey())))
+		mac.Write([]byte(t.signed))
+		if !hmac.Equal(mac.Sum(nil), t.signature) {
+			return ErrInvalidSignature
+		}
+	case "RS":
+		key, err := parseRSAPublicKey([]byte(unptr(secret.GetKey())))
+		if err != nil {
+			return err
+		}
+		h := hash.New()
+		h.Write([]byte(t.signed))
+		if err := rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), t.signature); err != nil {
+			return ErrInvalidSignature
+		}
+	}
+
+	if exp, ok := t.Claims["exp"].(float64); ok && time.Now().After(time.Unix(int64(exp), 0)) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// Secret returns the JWT secret hasura uses to verify tokens.
+func Secret(cfg *model.ConfigConfig) (*model.ConfigJWTSecret, error) {
+	secrets := cfg.GetHasura().GetJwtSecrets()
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("no jwt secret found in hasura.jwtSecrets") //nolint:goerr113
+	}
+	return secrets[0], nil
+}
+
+func hasuraClaimName(name string) string {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, hasuraClaimPrefix) {
+		return name
+	}
+	return hasuraClaimPrefix + name
+}
+
+// ParseClaims parses claims in the form key=value. Names are prefixed with
+// x-hasura- if they aren't already.
+func ParseClaims(claims []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(claims))
+	for _, claim := range claims {
+		k, v, found := strings.Cut(claim, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid claim %s, expected key=value", claim) //nolint:goerr113
+		}
+		parsed[hasuraClaimName(k)] = v
+	}
+	return parsed, nil
+}
+
+// CustomClaims resolves the claims configured in
+// auth.session.accessToken.customClaims against the user's data. Paths are
+// separated by dots and a trailing [] maps the rest of the path over an array,
+// for instance metadata.organisations[].id. Claims that can't be resolved are
+// left out.
+func CustomClaims(cfg *model.ConfigConfig, user map[string]any) map[string]string {
+	claims := map[string]string{}
+	for _, c := range cfg.GetAuth().GetSession().GetAccessToken().GetCustomClaims() {
+		v, ok := resolvePath(user, strings.Split(c.GetValue(), "."))
+		if !ok {
+			continue
+		}
+		claims[hasuraClaimName(c.GetKey())] = formatClaim(v)
+	}
+	return claims
+}
+
+func resolvePath(v any, path []string) (any, bool) {
+	if len(path) == 0 {
+		return v, v != nil
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	name, isArray := strings.CutSuffix(path[0], "[]")
+	v, ok = m[name]
+	if !ok {
+		return nil, false
+	}
+	if !isArray {
+		return resolvePath(v, path[1:])
+	}
+
+	elems, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	values := make([]any, 0, len(elems))
+	for _, e := range elems {
+		if r, ok := resolvePath(e, path[1:]); ok {
+			values = append(values, r)
+		}
+	}
+	return values, true
+}
+
+var arrayElemEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// formatClaim formats a value as a hasura session variable, arrays are
+// formatted as postgres array literals the way hasura-auth does.
+func formatClaim(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		elems := make([]string, len(v))
+		for i, e := range v {
+			elems[i] = `"` + arrayElemEscaper.Replace(formatClaim(e)) + `"`
+		}
+		return "{" + strings.Join(elems, ",") + "}"
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+// SessionClaims returns the hasura claims for a session with the given role
+// and user. The role defaults to auth.user.roles.default and is added to the
+// allowed roles if needed. Custom claims are resolved against user, which can
+// be nil, and are overridden by extra. Names of extra claims are prefixed with
+// x-hasura- if they aren't already.
+func SessionClaims(
+	cfg *model.ConfigConfig,
+	role string,
+	userID string,
+	user map[string]any,
+	extra map[string]string,
+) map[string]any {
+	roles := cfg.GetAuth().GetUser().GetRoles()
+	if role == "" {
+		role = unptr(roles.GetDefault())
+	}
+
+	allowedRoles := make([]string, 0, len(roles.GetAllowed())+1)
+	allowedRoles = append(allowedRoles, roles.GetAllowed()...)
+	found := false
+	for _, r := range allowedRoles {
+		if r == role {
+			found = true
+		}
+	}
+	if !found {
+		allowedRoles = append(allowedRoles, role)
+	}
+
+	claims := map[string]any{
+		"x-hasura-allowed-roles":     allowedRoles,
+		"x-hasura-default-role":      role,
+		"x-hasura-user-is-anonymous": "false",
+	}
+	if userID != "" {
+		claims["x-hasura-user-id"] = userID
+	}
+
+	for k, v := range CustomClaims(cfg, user) {
+		claims[k] = v
+	}
+
+	for k, v := range extra {
+		claims[hasuraClaimName(k)] = v
+	}
+
+	return claims
+}
+
+// Mint returns a token for the given session signed with the project's JWT
+// secret. expiresIn defaults to auth.session.accessToken.expiresIn.
+func Mint(
+	cfg *model.ConfigConfig,
+	role string,
+	userID string,
+	user map[string]any,
+	extra map[string]string,
+	expiresIn time.Duration,
+	privateKey []byte,
+) (string, error) {
+	secret, err := Secret(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if expiresIn == 0 {
+		expiresIn = time.Duration(
+			unptr(cfg.GetAuth().GetSession().GetAccessToken().GetExpiresIn()),
+		) * time.Second
+	}
+
+	claims := StandardClaims(secret, userID, expiresIn)
+	if err := HasuraClaims(
+		secret, claims, SessionClaims(cfg, role, userID, user, extra),
+	); err != nil {
+		return "", err
+	}
+
+	return Sign(secret, claims, privateKey)
+}
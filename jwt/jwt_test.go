@@ -0,0 +1,326 @@
+package jwt_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/jwt"
+)
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func getConfig(secret *model.ConfigJWTSecret) *model.ConfigConfig {
+	//nolint:exhaustruct
+	return &model.ConfigConfig{
+		Hasura: &model.ConfigHasura{
+			JwtSecrets: []*model.ConfigJWTSecret{secret},
+		},
+		Auth: &model.ConfigAuth{
+			User: &model.ConfigAuthUser{
+				Roles: &model.ConfigAuthUserRoles{
+					Default: ptr("user"),
+					Allowed: []string{"user", "me"},
+				},
+			},
+			Session: &model.ConfigAuthSession{
+				AccessToken: &model.ConfigAuthSessionAccessToken{
+					ExpiresIn: ptr(uint32(900)),
+				},
+			},
+		},
+	}
+}
+
+func rsaKeys(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+			Type:    "RSA PRIVATE KEY",
+			Headers: nil,
+			Bytes:   x509.MarshalPKCS1PrivateKey(key),
+		}), pem.EncodeToMemory(&pem.Block{
+			Type:    "PUBLIC KEY",
+			Headers: nil,
+			Bytes:   pub,
+		})
+}
+
+func TestMintAndVerify(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := rsaKeys(t)
+
+	cases := []struct {
+		name                 string
+		secret               *model.ConfigJWTSecret
+		privateKey           []byte
+		role                 string
+		expectedHasuraClaims any
+		expectedHasuraPath   []string
+	}{
+		{
+			name: "HS256",
+			secret: &model.ConfigJWTSecret{ //nolint:exhaustruct
+				Type: ptr("HS256"),
+				Key:  ptr("0f987876650b4a085e64594fae9219e7781b17506bec02489ad061fba8cb22db"),
+			},
+			role: "",
+			expectedHasuraClaims: map[string]any{
+				"x-hasura-allowed-roles":     []any{"user", "me"},
+				"x-hasura-default-role":      "user",
+				"x-hasura-user-id":           "00000000-0000-0000-0000-000000000001",
+				"x-hasura-user-is-anonymous": "false",
+				"x-hasura-org-id":            "acme",
+			},
+			expectedHasuraPath: []string{jwt.DefaultClaimsNamespace},
+		},
+		{
+			name: "HS512 with stringified claims",
+			secret: &model.ConfigJWTSecret{ //nolint:exhaustruct
+				Type:         ptr("HS512"),
+				Key:          ptr("0f987876650b4a085e64594fae9219e7781b17506bec02489ad061fba8cb22db"),
+				ClaimsFormat: ptr("stringified_json"),
+			},
+			role: "admin",
+			expectedHasuraClaims: `{"x-hasura-allowed-roles":["user","me","admin"],` +
+				`"x-hasura-default-role":"admin","x-hasura-org-id":"acme",` +
+				`"x-hasura-user-id":"00000000-0000-0000-0000-000000000001",` +
+				`"x-hasura-user-is-anonymous":"false"}`,
+			expectedHasuraPath: []string{jwt.DefaultClaimsNamespace},
+		},
+		{
+			name: "RS256 with namespace path",
+			secret: &model.ConfigJWTSecret{ //nolint:exhaustruct
+				Type:                ptr("RS256"),
+				Key:                 ptr(string(publicKey)),
+				ClaimsNamespacePath: ptr("$.hasura.claims"),
+			},
+			privateKey: privateKey,
+			role:       "me",
+			expectedHasuraClaims: map[string]any{
+				"x-hasura-allowed-roles":     []any{"user", "me"},
+				"x-hasura-default-role":      "me",
+				"x-hasura-user-id":           "00000000-0000-0000-0000-000000000001",
+				"x-hasura-user-is-anonymous": "false",
+				"x-hasura-org-id":            "acme",
+			},
+			expectedHasuraPath: []string{"hasura", "claims"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := getConfig(tc.secret)
+			token, err := jwt.Mint(
+				cfg,
+				tc.role,
+				"00000000-0000-0000-0000-000000000001",
+				nil,
+				map[string]string{"org-id": "acme"},
+				0,
+				tc.privateKey,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := jwt.Parse(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := parsed.Verify(tc.secret); err != nil {
+				t.Errorf("expected valid token, got: %v", err)
+			}
+
+			var got any = parsed.Claims
+			for _, p := range tc.expectedHasuraPath {
+				got = got.(map[string]any)[p] //nolint:forcetypeassert
+			}
+			if diff := cmp.Diff(tc.expectedHasuraClaims, got); diff != "" {
+				t.Error(diff)
+			}
+
+			exp := int64(parsed.Claims["exp"].(float64)) //nolint:forcetypeassert
+			iat := int64(parsed.Claims["iat"].(float64)) //nolint:forcetypeassert
+			if time.Duration(exp-iat)*time.Second != 900*time.Second {
+				t.Errorf("expected token to expire in 900s, got %ds", exp-iat)
+			}
+		})
+	}
+}
+
+func TestVerifyFailures(t *testing.T) {
+	t.Parallel()
+
+	secret := &model.ConfigJWTSecret{ //nolint:exhaustruct
+		Type: ptr("HS256"),
+		Key:  ptr("0f987876650b4a085e64594fae9219e7781b17506bec02489ad061fba8cb22db"),
+	}
+	otherSecret := &model.ConfigJWTSecret{ //nolint:exhaustruct
+		Type: ptr("HS256"),
+		Key:  ptr("another-secret-another-secret-another-secret"),
+	}
+
+	cases := []struct {
+		name        string
+		expiresIn   time.Duration
+		verifyWith  *model.ConfigJWTSecret
+		expectedErr error
+	}{
+		{
+			name:        "wrong secret",
+			expiresIn:   time.Hour,
+			verifyWith:  otherSecret,
+			expectedErr: jwt.ErrInvalidSignature,
+		},
+		{
+			name:        "expired",
+			expiresIn:   -time.Hour,
+			verifyWith:  secret,
+			expectedErr: jwt.ErrExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := jwt.Mint(getConfig(secret), "", "", nil, nil, tc.expiresIn, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := jwt.Parse(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := parsed.Verify(tc.verifyWith); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSignRSWithoutPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	_, publicKey := rsaKeys(t)
+	secret := &model.ConfigJWTSecret{ //nolint:exhaustruct
+		Type: ptr("RS256"),
+		Key:  ptr(string(publicKey)),
+	}
+
+	if _, err := jwt.Mint(getConfig(secret), "", "", nil, nil, 0, nil); err == nil {
+		t.Error("expected an error signing an RS256 token without a private key")
+	}
+}
+
+func TestSessionClaimsCustomClaims(t *testing.T) {
+	t.Parallel()
+
+	cfg := getConfig(&model.ConfigJWTSecret{}) //nolint:exhaustruct
+	cfg.Auth.Session.AccessToken.CustomClaims = []*model.ConfigAuthsessionaccessTokenCustomClaims{
+		{Key: "organisation-id", Value: "metadata.organisation.id"},
+		{Key: "project-ids", Value: "metadata.projects[].id"},
+		{Key: "locale", Value: "locale"},
+		{Key: "missing", Value: "metadata.missing"},
+	}
+
+	user := map[string]any{
+		"id":     "00000000-0000-0000-0000-000000000001",
+		"locale": "en",
+		"metadata": map[string]any{
+			"organisation": map[string]any{"id": "acme"},
+			"projects": []any{
+				map[string]any{"id": "p1"},
+				map[string]any{"id": "p2"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		user     map[string]any
+		extra    map[string]string
+		expected map[string]any
+	}{
+		{
+			name:  "resolved from user",
+			user:  user,
+			extra: nil,
+			expected: map[string]any{
+				"x-hasura-allowed-roles":     []string{"user", "me"},
+				"x-hasura-default-role":      "user",
+				"x-hasura-user-id":           "00000000-0000-0000-0000-000000000001",
+				"x-hasura-user-is-anonymous": "false",
+				"x-hasura-organisation-id":   "acme",
+				"x-hasura-project-ids":       `{"p1","p2"}`,
+				"x-hasura-locale":            "en",
+			},
+		},
+		{
+			name:  "overridden by extra claims",
+			user:  user,
+			extra: map[string]string{"organisation-id": "other"},
+			expected: map[string]any{
+				"x-hasura-allowed-roles":     []string{"user", "me"},
+				"x-hasura-default-role":      "user",
+				"x-hasura-user-id":           "00000000-0000-0000-0000-000000000001",
+				"x-hasura-user-is-anonymous": "false",
+				"x-hasura-organisation-id":   "other",
+				"x-hasura-project-ids":       `{"p1","p2"}`,
+				"x-hasura-locale":            "en",
+			},
+		},
+		{
+			name:  "no user",
+			user:  nil,
+			extra: nil,
+			expected: map[string]any{
+				"x-hasura-allowed-roles":     []string{"user", "me"},
+				"x-hasura-default-role":      "user",
+				"x-hasura-user-id":           "00000000-0000-0000-0000-000000000001",
+				"x-hasura-user-is-anonymous": "false",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := jwt.SessionClaims(
+				cfg, "", "00000000-0000-0000-0000-000000000001", tc.user, tc.extra,
+			)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
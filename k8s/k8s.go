@@ -0,0 +1,297 @@
+package k8s
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Only the subset of the kubernetes API needed to describe an nhost project is
+// modelled here.
+
+type Metadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+//nolint:tagliatelle
+type Namespace struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+}
+
+//nolint:tagliatelle
+type ConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+//nolint:tagliatelle
+type Secret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+//nolint:tagliatelle
+type PersistentVolumeClaim struct {
+	APIVersion string                    `yaml:"apiVersion"`
+	Kind       string                    `yaml:"kind"`
+	Metadata   Metadata                  `yaml:"metadata"`
+	Spec       PersistentVolumeClaimSpec `yaml:"spec"`
+}
+
+//nolint:tagliatelle
+type PersistentVolumeClaimSpec struct {
+	AccessModes []string             `yaml:"accessModes"`
+	Resources   ResourceRequirements `yaml:"resources"`
+}
+
+//nolint:tagliatelle
+type Service struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       ServiceSpec `yaml:"spec"`
+}
+
+type ServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []ServicePort     `yaml:"ports"`
+}
+
+//nolint:tagliatelle
+type ServicePort struct {
+	Name       string `yaml:"name"`
+	Port       uint   `yaml:"port"`
+	TargetPort uint   `yaml:"targetPort"`
+}
+
+//nolint:tagliatelle
+type Deployment struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   Metadata       `yaml:"metadata"`
+	Spec       DeploymentSpec `yaml:"spec"`
+}
+
+type DeploymentSpec struct {
+	Replicas uint8               `yaml:"replicas"`
+	Selector LabelSelector       `yaml:"selector"`
+	Strategy *DeploymentStrategy `yaml:"strategy,omitempty"`
+	Template PodTemplate         `yaml:"template"`
+}
+
+//nolint:tagliatelle
+type LabelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type DeploymentStrategy struct {
+	Type string `yaml:"type"`
+}
+
+type PodTemplate struct {
+	Metadata Metadata `yaml:"metadata"`
+	Spec     PodSpec  `yaml:"spec"`
+}
+
+type PodSpec struct {
+	Containers []Container `yaml:"containers"`
+	Volumes    []Volume    `yaml:"volumes,omitempty"`
+}
+
+//nolint:tagliatelle
+type Container struct {
+	Name           string                `yaml:"name"`
+	Image          string                `yaml:"image"`
+	Command        []string              `yaml:"command,omitempty"`
+	Args           []string              `yaml:"args,omitempty"`
+	Ports          []ContainerPort       `yaml:"ports,omitempty"`
+	EnvFrom        []EnvFromSource       `yaml:"envFrom,omitempty"`
+	Env            []EnvVar              `yaml:"env,omitempty"`
+	Resources      *ResourceRequirements `yaml:"resources,omitempty"`
+	ReadinessProbe *Probe                `yaml:"readinessProbe,omitempty"`
+	VolumeMounts   []VolumeMount         `yaml:"volumeMounts,omitempty"`
+}
+
+//nolint:tagliatelle
+type ContainerPort struct {
+	Name          string `yaml:"name"`
+	ContainerPort uint   `yaml:"containerPort"`
+}
+
+//nolint:tagliatelle
+type EnvFromSource struct {
+	ConfigMapRef *ObjectReference `yaml:"configMapRef,omitempty"`
+}
+
+type ObjectReference struct {
+	Name string `yaml:"name"`
+}
+
+//nolint:tagliatelle
+type EnvVar struct {
+	Name      string        `yaml:"name"`
+	Value     string        `yaml:"value,omitempty"`
+	ValueFrom *EnvVarSource `yaml:"valueFrom,omitempty"`
+}
+
+//nolint:tagliatelle
+type EnvVarSource struct {
+	SecretKeyRef *KeySelector `yaml:"secretKeyRef,omitempty"`
+}
+
+type KeySelector struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type ResourceRequirements struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
+}
+
+//nolint:tagliatelle
+type Probe struct {
+	HTTPGet             *HTTPGetAction `yaml:"httpGet,omitempty"`
+	Exec                *ExecAction    `yaml:"exec,omitempty"`
+	InitialDelaySeconds uint           `yaml:"initialDelaySeconds"`
+	PeriodSeconds       uint           `yaml:"periodSeconds"`
+}
+
+type HTTPGetAction struct {
+	Path string `yaml:"path"`
+	Port uint   `yaml:"port"`
+}
+
+type ExecAction struct {
+	Command []string `yaml:"command"`
+}
+
+//nolint:tagliatelle
+type VolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	SubPath   string `yaml:"subPath,omitempty"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+//nolint:tagliatelle
+type Volume struct {
+	Name                  string                             `yaml:"name"`
+	ConfigMap             *ObjectReference                   `yaml:"configMap,omitempty"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `yaml:"persistentVolumeClaim,omitempty"`
+}
+
+//nolint:tagliatelle
+type PersistentVolumeClaimVolumeSource struct {
+	ClaimName string `yaml:"claimName"`
+}
+
+//nolint:tagliatelle
+type Ingress struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       IngressSpec `yaml:"spec"`
+}
+
+//nolint:tagliatelle
+type IngressSpec struct {
+	IngressClassName string        `yaml:"ingressClassName,omitempty"`
+	TLS              []IngressTLS  `yaml:"tls,omitempty"`
+	Rules            []IngressRule `yaml:"rules"`
+}
+
+//nolint:tagliatelle
+type IngressTLS struct {
+	Hosts      []string `yaml:"hosts"`
+	SecretName string   `yaml:"secretName"`
+}
+
+type IngressRule struct {
+	Host string           `yaml:"host"`
+	HTTP IngressRuleValue `yaml:"http"`
+}
+
+type IngressRuleValue struct {
+	Paths []IngressPath `yaml:"paths"`
+}
+
+//nolint:tagliatelle
+type IngressPath struct {
+	Path     string         `yaml:"path"`
+	PathType string         `yaml:"pathType"`
+	Backend  IngressBackend `yaml:"backend"`
+}
+
+type IngressBackend struct {
+	Service IngressServiceBackend `yaml:"service"`
+}
+
+type IngressServiceBackend struct {
+	Name string             `yaml:"name"`
+	Port ServiceBackendPort `yaml:"port"`
+}
+
+type ServiceBackendPort struct {
+	Number uint `yaml:"number"`
+}
+
+// Render returns the objects as a multi-document YAML file.
+func Render(objects []any) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal object: %w", err)
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+// Credentials returns the postgres password and the minio credentials in the
+// Secrets of manifests rendered with Render. Values that aren't found are
+// returned empty.
+func Credentials(manifests []byte) (string, string, string, error) {
+	var postgresPassword, minioUser, minioPassword string
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var secret Secret
+		if err := dec.Decode(&secret); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return "", "", "", fmt.Errorf("failed to parse manifests: %w", err)
+		}
+		if secret.Kind != "Secret" {
+			continue
+		}
+
+		switch secret.Metadata.Name {
+		case "postgres":
+			postgresPassword = secret.StringData["root_password"]
+		case "minio":
+			minioUser = secret.StringData["rootUser"]
+			minioPassword = secret.StringData["rootPassword"]
+		}
+	}
+
+	return postgresPassword, minioUser, minioPassword, nil
+}
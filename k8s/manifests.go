@@ -0,0 +1,652 @@
+package k8s
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/be/services/mimir/schema/appconfig"
+	"github.com/nhost/cli/dockercompose"
+)
+
+const (
+	authPort      = 4000
+	storagePort   = 5000
+	functionsPort = 3000
+	hasuraPort    = 8080
+	postgresPort  = 5432
+	minioPort     = 9000
+)
+
+const (
+	database          = "local"
+	volumeSize        = "10Gi"
+	minioImage        = "minio/minio:RELEASE.2022-07-08T00-05-23Z"
+	rewriteAnnotation = "nginx.ingress.kubernetes.io/rewrite-target"
+	regexAnnotation   = "nginx.ingress.kubernetes.io/use-regex"
+)
+
+// Options configures the manifests rendered for a project.
+type Options struct {
+	// Namespace all objects are created in.
+	Namespace string
+	// Domain services are exposed at, for instance auth.<domain>.
+	Domain string
+	// IngressClass of the ingresses. Path rewrites rely on ingress-nginx
+	// annotations.
+	IngressClass string
+	// TLSSecret is the name of the secret with the certificate for the
+	// services' hosts. If empty ingresses are served over plain HTTP.
+	TLSSecret string
+	// FunctionsImage is an image with the project's functions built on top of
+	// nhost/functions. Functions are skipped if empty.
+	FunctionsImage string
+	// PostgresPassword is the password of the postgres superuser.
+	PostgresPassword string
+	// MinioUser and MinioPassword are the credentials of the S3 storage.
+	MinioUser     string
+	MinioPassword string
+}
+
+func (o Options) host(service string) string {
+	return fmt.Sprintf("%s.%s", service, o.Domain)
+}
+
+func (o Options) scheme() string {
+	if o.TLSSecret != "" {
+		return "https"
+	}
+	return "http"
+}
+
+// URL returns the public URL of the service.
+func (o Options) URL(service string) string {
+	return o.scheme() + "://" + o.host(service)
+}
+
+// urlsEnv returns the variables pointing to the public URLs of the services.
+func (o Options) urlsEnv() []appconfig.EnvVar {
+	vars := map[string]string{
+		"NHOST_AUTH_URL":      o.URL("auth") + "/v1",
+		"NHOST_BACKEND_URL":   o.scheme() + "://" + o.Domain,
+		"NHOST_FUNCTIONS_URL": o.URL("functions") + "/v1",
+		"NHOST_GRAPHQL_URL":   o.URL("graphql") + "/v1",
+		"NHOST_HASURA_URL":    o.URL("hasura") + "/console",
+		"NHOST_STORAGE_URL":   o.URL("storage") + "/v1",
+	}
+
+	env := make([]appconfig.EnvVar, 0, len(vars))
+	for k, v := range vars {
+		env = append(env, appconfig.EnvVar{Name: k, Value: v}) //nolint:exhaustruct
+	}
+	return env
+}
+
+func labels(name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":    name,
+		"app.kubernetes.io/part-of": "nhost",
+	}
+}
+
+// metadata returns the metadata of an object of the service app.
+func (o Options) metadata(name, app string) Metadata {
+	return Metadata{
+		Name:        name,
+		Namespace:   o.Namespace,
+		Labels:      labels(app),
+		Annotations: nil,
+	}
+}
+
+// withOverrides returns env with the variables in overrides replacing the
+// ones with the same name.
+func withOverrides(env []appconfig.EnvVar, overrides ...appconfig.EnvVar) []appconfig.EnvVar {
+	idx := make(map[string]int, len(env))
+	res := make([]appconfig.EnvVar, 0, len(env)+len(overrides))
+	for _, v := range append(env, overrides...) {
+		if i, ok := idx[v.Name]; ok {
+			res[i] = v
+			continue
+		}
+		idx[v.Name] = len(res)
+		res = append(res, v)
+	}
+	return res
+}
+
+// databaseURL returns the URL to connect to the postgres service as role with
+// the password set by the init script of the postgres service.
+func (o Options) databaseURL(role, params string) string {
+	u := url.URL{ //nolint:exhaustruct
+		Scheme:   "postgres",
+		User:     url.UserPassword(role, o.PostgresPassword),
+		Host:     fmt.Sprintf("postgres:%d", postgresPort),
+		Path:     "/" + database,
+		RawQuery: params,
+	}
+	return u.String()
+}
+
+// env splits the environment of a service in a ConfigMap, with the plain
+// variables, and a Secret, with the secret ones. The container references
+// both.
+func (o Options) env(name string, envars []appconfig.EnvVar) (*ConfigMap, *Secret, []EnvFromSource, []EnvVar) {
+	cm := &ConfigMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   o.metadata(name+"-env", name),
+		Data:       map[string]string{},
+	}
+	secret := &Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   o.metadata(name, name),
+		Type:       "Opaque",
+		StringData: map[string]string{},
+	}
+
+	var refs []EnvVar
+	for _, v := range envars {
+		if !v.IsSecret {
+			cm.Data[v.Name] = v.Value
+			continue
+		}
+
+		secret.StringData[v.SecretName] = v.Value
+		refs = append(refs, EnvVar{
+			Name:  v.Name,
+			Value: "",
+			ValueFrom: &EnvVarSource{
+				SecretKeyRef: &KeySelector{Name: name, Key: v.SecretName},
+			},
+		})
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Name < refs[j].Name })
+
+	return cm, secret, []EnvFromSource{{ConfigMapRef: &ObjectReference{Name: cm.Metadata.Name}}}, refs
+}
+
+func resources(r *model.ConfigResources) *ResourceRequirements {
+	compute := r.GetCompute()
+	if compute == nil {
+		return nil
+	}
+
+	values := map[string]string{
+		"cpu":    fmt.Sprintf("%dm", compute.Cpu),
+		"memory": fmt.Sprintf("%dMi", compute.Memory),
+	}
+	return &ResourceRequirements{Requests: values, Limits: values}
+}
+
+func replicas(r *model.ConfigResources) uint8 {
+	if r.GetReplicas() == 0 {
+		return 1
+	}
+	return r.GetReplicas()
+}
+
+func httpProbe(path string, port uint) *Probe {
+	return &Probe{
+		HTTPGet:             &HTTPGetAction{Path: path, Port: port},
+		Exec:                nil,
+		InitialDelaySeconds: 5,  //nolint:gomnd
+		PeriodSeconds:       10, //nolint:gomnd
+	}
+}
+
+func (o Options) persistentVolumeClaim(name string) *PersistentVolumeClaim {
+	return &PersistentVolumeClaim{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Metadata:   o.metadata(name, name),
+		Spec: PersistentVolumeClaimSpec{
+			AccessModes: []string{"ReadWriteOnce"},
+			Resources: ResourceRequirements{
+				Requests: map[string]string{"storage": volumeSize},
+				Limits:   nil,
+			},
+		},
+	}
+}
+
+func (o Options) service(name string, port uint) *Service {
+	return &Service{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   o.metadata(name, name),
+		Spec: ServiceSpec{
+			Selector: labels(name),
+			Ports:    []ServicePort{{Name: "http", Port: port, TargetPort: port}},
+		},
+	}
+}
+
+func (o Options) deployment(
+	name string,
+	replicas uint8,
+	strategy *DeploymentStrategy,
+	container Container,
+	volumes []Volume,
+) *Deployment {
+	return &Deployment{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   o.metadata(name, name),
+		Spec: DeploymentSpec{
+			Replicas: replicas,
+			Selector: LabelSelector{MatchLabels: labels(name)},
+			Strategy: strategy,
+			Template: PodTemplate{
+				Metadata: Metadata{
+					Name:        name,
+					Namespace:   "",
+					Labels:      labels(name),
+					Annotations: nil,
+				},
+				Spec: PodSpec{
+					Containers: []Container{container},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}
+}
+
+// ingress exposes a service at <host>.<domain>. If rewrite is not empty
+// requests matching path, a regular expression, are rewritten to it.
+func (o Options) ingress(host, service string, port uint, path, rewrite string) *Ingress {
+	meta := o.metadata(host, service)
+	pathType := "Prefix"
+	if rewrite != "" {
+		pathType = "ImplementationSpecific"
+		meta.Annotations = map[string]string{
+			regexAnnotation:   "true",
+			rewriteAnnotation: rewrite,
+		}
+	}
+
+	var tls []IngressTLS
+	if o.TLSSecret != "" {
+		tls = []IngressTLS{{Hosts: []string{o.host(host)}, SecretName: o.TLSSecret}}
+	}
+
+	return &Ingress{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Metadata:   meta,
+		Spec: IngressSpec{
+			IngressClassName: o.IngressClass,
+			TLS:              tls,
+			Rules: []IngressRule{
+				{
+					Host: o.host(host),
+					HTTP: IngressRuleValue{
+						Paths: []IngressPath{
+							{
+								Path:     path,
+								PathType: pathType,
+								Backend: IngressBackend{
+									Service: IngressServiceBackend{
+										Name: service,
+										Port: ServiceBackendPort{Number: port},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (o Options) postgres(cfg *model.ConfigConfig) ([]any, error) {
+	envars, err := appconfig.PostgresEnv(database, "postgres", o.PostgresPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postgres env vars: %w", err)
+	}
+	cm, secret, envFrom, env := o.env("postgres", envars)
+
+	hba := &ConfigMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   o.metadata("postgres-config", "postgres"),
+		Data: map[string]string{
+			"pg_hba.conf": dockercompose.ExportPgHba,
+			"initdb.sh":   dockercompose.ExportPostgresInitScript(),
+		},
+	}
+
+	container := Container{
+		Name:    "postgres",
+		Image:   fmt.Sprintf("nhost/postgres:%s", *cfg.GetPostgres().GetVersion()),
+		Command: nil,
+		Args: []string{
+			"postgres",
+			"-c", "config_file=/etc/postgresql.conf",
+			"-c", "hba_file=/etc/pg_hba_k8s.conf",
+		},
+		Ports:     []ContainerPort{{Name: "postgres", ContainerPort: postgresPort}},
+		EnvFrom:   envFrom,
+		Env:       env,
+		Resources: resources(cfg.GetPostgres().GetResources()),
+		ReadinessProbe: &Probe{
+			HTTPGet:             nil,
+			Exec:                &ExecAction{Command: []string{"pg_isready", "-U", "postgres"}},
+			InitialDelaySeconds: 5,  //nolint:gomnd
+			PeriodSeconds:       10, //nolint:gomnd
+		},
+		VolumeMounts: []VolumeMount{
+			{Name: "data", MountPath: "/var/lib/postgresql/data", SubPath: "", ReadOnly: false},
+			{Name: "config", MountPath: "/etc/pg_hba_k8s.conf", SubPath: "pg_hba.conf", ReadOnly: true},
+			{
+				Name:      "config",
+				MountPath: "/docker-entrypoint-initdb.d/zz-nhost-passwords.sh",
+				SubPath:   "initdb.sh",
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	// postgres can't be scaled horizontally and its volume can only be
+	// mounted by one pod at a time
+	deployment := o.deployment(
+		"postgres", 1, &DeploymentStrategy{Type: "Recreate"}, container, []Volume{
+			{
+				Name:                  "data",
+				ConfigMap:             nil,
+				PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{ClaimName: "postgres"},
+			},
+			{
+				Name:                  "config",
+				ConfigMap:             &ObjectReference{Name: hba.Metadata.Name},
+				PersistentVolumeClaim: nil,
+			},
+		},
+	)
+
+	return []any{
+		cm, secret, hba, o.persistentVolumeClaim("postgres"),
+		deployment, o.service("postgres", postgresPort),
+	}, nil
+}
+
+func (o Options) hasura(cfg *model.ConfigConfig) ([]any, error) {
+	envars, err := appconfig.HasuraEnv(
+		cfg,
+		"",
+		"",
+		o.Domain,
+		o.databaseURL("nhost_hasura", ""),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hasura env vars: %w", err)
+	}
+	envars = withOverrides(envars, o.urlsEnv()...)
+	cm, secret, envFrom, env := o.env("hasura", envars)
+
+	container := Container{
+		Name:           "hasura",
+		Image:          fmt.Sprintf("nhost/graphql-engine:%s", *cfg.GetHasura().GetVersion()),
+		Command:        nil,
+		Args:           nil,
+		Ports:          []ContainerPort{{Name: "http", ContainerPort: hasuraPort}},
+		EnvFrom:        envFrom,
+		Env:            env,
+		Resources:      resources(cfg.GetHasura().GetResources()),
+		ReadinessProbe: httpProbe("/healthz", hasuraPort),
+		VolumeMounts:   nil,
+	}
+
+	return []any{
+		cm, secret,
+		o.deployment("hasura", replicas(cfg.GetHasura().GetResources()), nil, container, nil),
+		o.service("hasura", hasuraPort),
+		o.ingress("graphql", "hasura", hasuraPort, "/v1(/|$)(.*)", "/v1/graphql$2"),
+		o.ingress("hasura", "hasura", hasuraPort, "/", ""),
+	}, nil
+}
+
+func (o Options) auth(cfg *model.ConfigConfig) ([]any, error) {
+	envars, err := appconfig.HasuraAuthEnv(
+		cfg,
+		fmt.Sprintf("http://hasura:%d/v1/graphql", hasuraPort),
+		o.URL("auth")+"/v1",
+		o.databaseURL("nhost_auth_admin", ""),
+		cfg.GetProvider().GetSmtp(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth env vars: %w", err)
+	}
+	cm, secret, envFrom, env := o.env("auth", envars)
+
+	container := Container{
+		Name:           "auth",
+		Image:          fmt.Sprintf("nhost/hasura-auth:%s", *cfg.GetAuth().GetVersion()),
+		Command:        nil,
+		Args:           nil,
+		Ports:          []ContainerPort{{Name: "http", ContainerPort: authPort}},
+		EnvFrom:        envFrom,
+		Env:            env,
+		Resources:      resources(cfg.GetAuth().GetResources()),
+		ReadinessProbe: httpProbe("/healthz", authPort),
+		VolumeMounts:   nil,
+	}
+
+	return []any{
+		cm, secret,
+		o.deployment("auth", replicas(cfg.GetAuth().GetResources()), nil, container, nil),
+		o.service("auth", authPort),
+		o.ingress("auth", "auth", authPort, "/v1(/|$)(.*)", "/$2"),
+	}, nil
+}
+
+func (o Options) minio() []any {
+	secret := &Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   o.metadata("minio", "minio"),
+		Type:       "Opaque",
+		StringData: map[string]string{
+			"rootUser":     o.MinioUser,
+			"rootPassword": o.MinioPassword,
+		},
+	}
+
+	container := Container{
+		Name:    "minio",
+		Image:   minioImage,
+		Command: []string{"/bin/sh"},
+		Args: []string{
+			"-c", fmt.Sprintf("mkdir -p /data/nhost && /opt/bin/minio server --address :%d /data", minioPort),
+		},
+		Ports:   []ContainerPort{{Name: "http", ContainerPort: minioPort}},
+		EnvFrom: nil,
+		Env: []EnvVar{
+			{
+				Name:  "MINIO_ROOT_PASSWORD",
+				Value: "",
+				ValueFrom: &EnvVarSource{
+					SecretKeyRef: &KeySelector{Name: "minio", Key: "rootPassword"},
+				},
+			},
+			{
+				Name:  "MINIO_ROOT_USER",
+				Value: "",
+				ValueFrom: &EnvVarSource{
+					SecretKeyRef: &KeySelector{Name: "minio", Key: "rootUser"},
+				},
+			},
+		},
+		Resources:      nil,
+		ReadinessProbe: httpProbe("/minio/health/ready", minioPort),
+		VolumeMounts: []VolumeMount{
+			{Name: "data", MountPath: "/data", SubPath: "", ReadOnly: false},
+		},
+	}
+
+	deployment := o.deployment(
+		"minio", 1, &DeploymentStrategy{Type: "Recreate"}, container, []Volume{
+			{
+				Name:                  "data",
+				ConfigMap:             nil,
+				PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{ClaimName: "minio"},
+			},
+		},
+	)
+
+	return []any{
+		secret, o.persistentVolumeClaim("minio"), deployment, o.service("minio", minioPort),
+	}
+}
+
+func (o Options) storage(cfg *model.ConfigConfig) ([]any, error) {
+	envars, err := appconfig.HasuraStorageEnv(
+		cfg,
+		fmt.Sprintf("http://hasura:%d/v1", hasuraPort),
+		o.databaseURL("nhost_storage_admin", "sslmode=disable"),
+		o.URL("storage"),
+		fmt.Sprintf("http://minio:%d", minioPort),
+		"",
+		"nhost",
+		"",
+		o.MinioUser,
+		o.MinioPassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage env vars: %w", err)
+	}
+	cm, secret, envFrom, env := o.env("storage", envars)
+
+	container := Container{
+		Name:           "storage",
+		Image:          fmt.Sprintf("nhost/hasura-storage:%s", *cfg.GetStorage().GetVersion()),
+		Command:        nil,
+		Args:           []string{"serve"},
+		Ports:          []ContainerPort{{Name: "http", ContainerPort: storagePort}},
+		EnvFrom:        envFrom,
+		Env:            env,
+		Resources:      resources(cfg.GetStorage().GetResources()),
+		ReadinessProbe: httpProbe("/healthz", storagePort),
+		VolumeMounts:   nil,
+	}
+
+	return []any{
+		cm, secret,
+		o.deployment("storage", replicas(cfg.GetStorage().GetResources()), nil, container, nil),
+		o.service("storage", storagePort),
+		o.ingress("storage", "storage", storagePort, "/v1", ""),
+	}, nil
+}
+
+func (o Options) functions(cfg *model.ConfigConfig) ([]any, error) {
+	hasuraEnv, err := appconfig.HasuraEnv(cfg, "", "", o.Domain, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hasura env vars: %w", err)
+	}
+
+	envars := []appconfig.EnvVar{
+		{
+			Name:       "HASURA_GRAPHQL_ADMIN_SECRET",
+			Value:      cfg.GetHasura().GetAdminSecret(),
+			SecretName: "adminSecret",
+			IsSecret:   true,
+		},
+		{
+			Name:       "HASURA_GRAPHQL_DATABASE_URL",
+			Value:      o.databaseURL("nhost_auth_admin", ""),
+			SecretName: "databaseUrl",
+			IsSecret:   true,
+		},
+		{
+			Name:       "HASURA_GRAPHQL_GRAPHQL_URL",
+			Value:      fmt.Sprintf("http://hasura:%d/v1/graphql", hasuraPort),
+			SecretName: "",
+			IsSecret:   false,
+		},
+		{
+			Name:       "NHOST_ADMIN_SECRET",
+			Value:      cfg.GetHasura().GetAdminSecret(),
+			SecretName: "adminSecret",
+			IsSecret:   true,
+		},
+		{
+			Name:       "NHOST_WEBHOOK_SECRET",
+			Value:      cfg.GetHasura().GetWebhookSecret(),
+			SecretName: "webhookSecret",
+			IsSecret:   true,
+		},
+	}
+	for _, v := range hasuraEnv {
+		if v.Name == "HASURA_GRAPHQL_JWT_SECRET" || v.Name == "NHOST_JWT_SECRET" {
+			envars = append(envars, v)
+		}
+	}
+	envars = withOverrides(envars, o.urlsEnv()...)
+	for _, v := range cfg.GetGlobal().GetEnvironment() {
+		envars = withOverrides(envars, appconfig.EnvVar{Name: v.GetName(), Value: v.GetValue()}) //nolint:exhaustruct
+	}
+	cm, secret, envFrom, env := o.env("functions", envars)
+
+	container := Container{
+		Name:           "functions",
+		Image:          o.FunctionsImage,
+		Command:        nil,
+		Args:           nil,
+		Ports:          []ContainerPort{{Name: "http", ContainerPort: functionsPort}},
+		EnvFrom:        envFrom,
+		Env:            env,
+		Resources:      nil,
+		ReadinessProbe: httpProbe("/healthz", functionsPort),
+		VolumeMounts:   nil,
+	}
+
+	return []any{
+		cm, secret,
+		o.deployment("functions", 1, nil, container, nil),
+		o.service("functions", functionsPort),
+		o.ingress("functions", "functions", functionsPort, "/v1(/|$)(.*)", "/$2"),
+	}, nil
+}
+
+// Manifests returns the objects needed to run the project described by cfg,
+// which must be validated and have its secrets resolved, in a kubernetes
+// cluster.
+func Manifests(cfg *model.ConfigConfig, opts Options) ([]any, error) {
+	objects := []any{
+		&Namespace{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Metadata: Metadata{
+				Name:        opts.Namespace,
+				Namespace:   "",
+				Labels:      map[string]string{"app.kubernetes.io/part-of": "nhost"},
+				Annotations: nil,
+			},
+		},
+	}
+
+	builders := []func(*model.ConfigConfig) ([]any, error){
+		opts.postgres,
+		opts.hasura,
+		opts.auth,
+		func(*model.ConfigConfig) ([]any, error) { return opts.minio(), nil },
+		opts.storage,
+	}
+	if opts.FunctionsImage != "" {
+		builders = append(builders, opts.functions)
+	}
+
+	for _, build := range builders {
+		objs, err := build(cfg)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,157 @@
+package k8s_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/be/services/mimir/schema"
+	"github.com/nhost/be/services/mimir/schema/appconfig"
+	"github.com/nhost/cli/k8s"
+	"github.com/nhost/cli/project/env"
+	"github.com/pelletier/go-toml/v2"
+)
+
+func loadConfig(t *testing.T) *model.ConfigConfig {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/nhost.toml")
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	cfg := &model.ConfigConfig{} //nolint:exhaustruct
+	if err := toml.Unmarshal(b, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	b, err = os.ReadFile("testdata/.secrets")
+	if err != nil {
+		t.Fatalf("failed to read secrets: %v", err)
+	}
+	var secrets model.Secrets
+	if err := env.Unmarshal(b, &secrets); err != nil {
+		t.Fatalf("failed to parse secrets: %v", err)
+	}
+
+	sch, err := schema.New()
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	cfg, err = appconfig.Config(sch, cfg, secrets)
+	if err != nil {
+		t.Fatalf("failed to validate config: %v", err)
+	}
+	return cfg
+}
+
+func TestManifests(t *testing.T) {
+	t.Parallel()
+
+	cfg := loadConfig(t)
+
+	cases := []struct {
+		name   string
+		opts   k8s.Options
+		golden string
+	}{
+		{
+			name: "http without functions",
+			opts: k8s.Options{
+				Namespace:        "my-app",
+				Domain:           "example.com",
+				IngressClass:     "nginx",
+				TLSSecret:        "",
+				FunctionsImage:   "",
+				PostgresPassword: "postgresPassword",
+				MinioUser:        "minioUser",
+				MinioPassword:    "minioPassword",
+			},
+			golden: "testdata/manifests.yaml",
+		},
+		{
+			name: "tls with functions",
+			opts: k8s.Options{
+				Namespace:        "my-app",
+				Domain:           "example.com",
+				IngressClass:     "nginx",
+				TLSSecret:        "example-com-tls",
+				FunctionsImage:   "registry.example.com/my-app/functions:1.0.0",
+				PostgresPassword: "postgresPassword",
+				MinioUser:        "minioUser",
+				MinioPassword:    "minioPassword",
+			},
+			golden: "testdata/manifests-tls-functions.yaml",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			objects, err := k8s.Manifests(cfg, tc.opts)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			got, err := k8s.Render(objects)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			expected, err := os.ReadFile(tc.golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if diff := cmp.Diff(string(expected), string(got)); diff != "" {
+				t.Errorf("manifests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	t.Parallel()
+
+	golden, err := os.ReadFile("testdata/manifests.yaml")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		manifests []byte
+		expected  []string
+	}{
+		{
+			name:      "rendered manifests",
+			manifests: golden,
+			expected:  []string{"postgresPassword", "minioUser", "minioPassword"},
+		},
+		{
+			name:      "empty",
+			manifests: []byte{},
+			expected:  []string{"", "", ""},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			postgresPassword, minioUser, minioPassword, err := k8s.Credentials(tc.manifests)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			got := []string{postgresPassword, minioUser, minioPassword}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("credentials mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
-	"errors"
-	"log"
 	"os"
 
-	"github.com/Yamashou/gqlgenc/clientv2"
 	"github.com/nhost/cli/clienv"
+	"github.com/nhost/cli/cmd/completion"
 	"github.com/nhost/cli/cmd/config"
+	"github.com/nhost/cli/cmd/contexts"
 	"github.com/nhost/cli/cmd/dev"
+	"github.com/nhost/cli/cmd/env"
+	"github.com/nhost/cli/cmd/export"
+	"github.com/nhost/cli/cmd/graphql"
+	"github.com/nhost/cli/cmd/plugin"
 	"github.com/nhost/cli/cmd/project"
+	"github.com/nhost/cli/cmd/run"
 	"github.com/nhost/cli/cmd/secrets"
+	"github.com/nhost/cli/cmd/settings"
 	"github.com/nhost/cli/cmd/software"
 	"github.com/nhost/cli/cmd/user"
 	"github.com/urfave/cli/v2"
@@ -30,33 +35,41 @@ func main() {
 		Version:              Version,
 		Description:          "Nhost CLI tool",
 		Commands: []*cli.Command{
+			completion.Command(),
 			config.Command(),
+			contexts.Command(),
 			dev.Command(),
 			dev.CommandUp(),
 			dev.CommandDown(),
 			dev.CommandLogs(),
+			dev.CommandTest(),
+			env.Command(),
+			export.Command(),
+			graphql.Command(),
+			plugin.Command(),
 			project.CommandInit(),
 			project.CommandList(),
 			project.CommandLink(),
+			run.Command(),
 			secrets.Command(),
+			settings.Command(),
 			software.Command(),
+			user.Command(),
 			user.CommandLogin(),
 		},
 		Metadata: map[string]any{
 			"Author":  "Nhost",
 			"LICENSE": "MIT",
 		},
-		Flags: flags,
+		Flags:                 flags,
+		Before:                clienv.ApplySettings(""),
+		ExitErrHandler:        clienv.ExitErrHandler,
+		CommandNotFound:       plugin.CommandNotFound,
+		CustomAppHelpTemplate: plugin.HelpTemplate,
 	}
+	app.ExtraInfo = plugin.HelpExtraInfo(app)
 
 	if err := app.Run(os.Args); err != nil {
-		var graphqlErr *clientv2.ErrorResponse
-
-		switch {
-		case errors.As(err, &graphqlErr):
-			log.Fatal(graphqlErr.GqlErrors)
-		case err != nil:
-			log.Fatal(err)
-		}
+		os.Exit(clienv.PrintError(app.ErrWriter, err, clienv.DebugRequested(os.Args)))
 	}
 }
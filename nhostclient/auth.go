@@ -3,7 +3,6 @@ package nhostclient
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -13,6 +12,8 @@ import (
 
 const (
 	PATDuration = 90 * 24 * time.Hour
+	// PATApplication identifies in their metadata the PATs created by the CLI.
+	PATApplication = "nhost-cli"
 )
 
 type LoginRequest struct {
@@ -33,13 +34,7 @@ func (n *Client) Login(ctx context.Context, email, password string) (credentials
 		},
 		http.Header{},
 		&resp,
-		func(resp *http.Response) error {
-			if resp.StatusCode != http.StatusOK {
-				b, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("unexpected status code: %d, message: %s", resp.StatusCode, string(b)) //nolint:goerr113
-			}
-			return nil
-		},
+		validateStatusOK,
 		n.retryer,
 	); err != nil {
 		return credentials.Session{}, fmt.Errorf("failed to login: %w", err)
@@ -64,13 +59,7 @@ func (n *Client) LoginPAT(ctx context.Context, pat string) (credentials.Session,
 		},
 		http.Header{},
 		&resp,
-		func(resp *http.Response) error {
-			if resp.StatusCode != http.StatusOK {
-				b, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("unexpected status code: %d, message: %s", resp.StatusCode, string(b)) //nolint:goerr113
-			}
-			return nil
-		},
+		validateStatusOK,
 		n.retryer,
 	); err != nil {
 		return credentials.Session{}, fmt.Errorf("failed to login: %w", err)
@@ -88,6 +77,8 @@ func (n *Client) CreatePAT(
 	ctx context.Context,
 	accessToken string,
 ) (credentials.Credentials, error) {
+	expiresAt := time.Now().Add(PATDuration)
+
 	var resp credentials.Credentials
 	if err := MakeJSONRequest(
 		ctx,
@@ -95,26 +86,21 @@ func (n *Client) CreatePAT(
 		fmt.Sprintf("%s%s", n.baseURL, "/pat"),
 		http.MethodPost,
 		CreatePATRequest{
-			ExpiresAt: time.Now().Add(PATDuration),
+			ExpiresAt: expiresAt,
 			Metadata: map[string]any{
-				"application": "nhost-cli",
+				"application": PATApplication,
 			},
 		},
 		http.Header{
 			"Authorization": []string{fmt.Sprintf("Bearer %s", accessToken)},
 		},
 		&resp,
-		func(resp *http.Response) error {
-			if resp.StatusCode != http.StatusOK {
-				b, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("unexpected status code: %d, message: %s", resp.StatusCode, string(b)) //nolint:goerr113
-			}
-			return nil
-		},
+		validateStatusOK,
 		n.retryer,
 	); err != nil {
 		return credentials.Credentials{}, fmt.Errorf("failed to create PAT: %w", err)
 	}
+	resp.ExpiresAt = expiresAt
 
 	return resp, nil
 }
@@ -134,3 +120,76 @@ func (n *Client) Logout(ctx context.Context, refreshToken string, accessToken st
 	}
 	return nil
 }
+
+// GetPATs returns the PATs of the user created by the CLI.
+func (n *Client) GetPATs(
+	ctx context.Context,
+	userID string,
+	accessToken string,
+) ([]*graphql.GetPersonalAccessTokens_AuthRefreshTokens, error) {
+	patType := "pat"
+	resp, err := n.GetPersonalAccessTokens(
+		ctx,
+		//nolint:exhaustruct
+		graphql.AuthRefreshTokensBoolExp{
+			UserID: &graphql.UUIDComparisonExp{
+				Eq: &userID,
+			},
+			Type: &graphql.RefreshTokenTypeComparisonExp{
+				Eq: &patType,
+			},
+			Metadata: &graphql.JsonbComparisonExp{
+				Contains: map[string]any{
+					"application": PATApplication,
+				},
+			},
+		},
+		graphql.WithAccessToken(accessToken),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PATs: %w", err)
+	}
+	return resp.AuthRefreshTokens, nil
+}
+
+// DeletePATByHash deletes the PAT whose refreshTokenHash, as returned by
+// GetPATs, is hash.
+func (n *Client) DeletePATByHash(ctx context.Context, hash string, accessToken string) error {
+	patType := "pat"
+	if _, err := n.DeleteRefreshToken(
+		ctx,
+		//nolint:exhaustruct
+		graphql.AuthRefreshTokensBoolExp{
+			RefreshTokenHash: &graphql.StringComparisonExp{
+				Eq: &hash,
+			},
+			Type: &graphql.RefreshTokenTypeComparisonExp{
+				Eq: &patType,
+			},
+		},
+		graphql.WithAccessToken(accessToken),
+	); err != nil {
+		return fmt.Errorf("failed to delete PAT: %w", err)
+	}
+	return nil
+}
+
+func (n *Client) DeletePAT(ctx context.Context, pat string, accessToken string) error {
+	patType := "pat"
+	if _, err := n.DeleteRefreshToken(
+		ctx,
+		//nolint:exhaustruct
+		graphql.AuthRefreshTokensBoolExp{
+			RefreshToken: &graphql.UUIDComparisonExp{
+				Eq: &pat,
+			},
+			Type: &graphql.RefreshTokenTypeComparisonExp{
+				Eq: &patType,
+			},
+		},
+		graphql.WithAccessToken(accessToken),
+	); err != nil {
+		return fmt.Errorf("failed to delete PAT: %w", err)
+	}
+	return nil
+}
@@ -1,5 +1,8 @@
 package credentials
 
+import "time"
+
 type Credentials struct {
-	PersonalAccessToken string `json:"personalAccessToken"`
+	PersonalAccessToken string    `json:"personalAccessToken"`
+	ExpiresAt           time.Time `json:"expiresAt"`
 }
@@ -5,5 +5,9 @@ type Session struct {
 		AccessToken          string `json:"accessToken"`
 		AccessTokenExpiresIn int    `json:"accessTokenExpiresIn"`
 		RefreshToken         string `json:"refreshToken"`
+		User                 struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		} `json:"user"`
 	} `json:"session"`
 }
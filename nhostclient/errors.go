@@ -0,0 +1,54 @@
+package nhostclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned when the Nhost API responds with an unexpected status
+// code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, message: %s", e.StatusCode, e.Message)
+}
+
+// NetworkError is returned when a request to the Nhost API can't be sent or
+// its response can't be received, for instance because there is no internet
+// connection.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// networkTransport returns the errors of transport as *NetworkError.
+type networkTransport struct {
+	transport http.RoundTripper
+}
+
+func (t networkTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, &NetworkError{Err: err}
+	}
+	return resp, nil
+}
+
+func validateStatusOK(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return &APIError{StatusCode: resp.StatusCode, Message: string(b)}
+	}
+	return nil
+}
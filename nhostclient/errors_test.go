@@ -0,0 +1,30 @@
+package nhostclient_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/nhost/cli/nhostclient"
+)
+
+func TestNetworkError(t *testing.T) {
+	t.Parallel()
+
+	// nothing listens on the address of a closed listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	cl := nhostclient.New(addr)
+	_, err = cl.GetWorkspacesApps(context.Background())
+
+	var netErr *nhostclient.NetworkError
+	if !errors.As(err, &netErr) {
+		t.Errorf("expected a *NetworkError, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package graphql
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Yamashou/gqlgenc/clientv2"
 )
@@ -490,14 +491,14 @@ func (t *GetHasuraAdminSecret_App) GetConfig() *GetHasuraAdminSecret_App_Config
 }
 
 type DeleteRefreshToken_DeleteAuthRefreshTokens_Returning struct {
-	RefreshToken string "json:\"refreshToken\" graphql:\"refreshToken\""
+	RefreshTokenHash *string "json:\"refreshTokenHash,omitempty\" graphql:\"refreshTokenHash\""
 }
 
-func (t *DeleteRefreshToken_DeleteAuthRefreshTokens_Returning) GetRefreshToken() string {
+func (t *DeleteRefreshToken_DeleteAuthRefreshTokens_Returning) GetRefreshTokenHash() *string {
 	if t == nil {
 		t = &DeleteRefreshToken_DeleteAuthRefreshTokens_Returning{}
 	}
-	return t.RefreshToken
+	return t.RefreshTokenHash
 }
 
 type DeleteRefreshToken_DeleteAuthRefreshTokens struct {
@@ -518,6 +519,31 @@ func (t *DeleteRefreshToken_DeleteAuthRefreshTokens) GetReturning() []*DeleteRef
 	return t.Returning
 }
 
+type GetPersonalAccessTokens_AuthRefreshTokens struct {
+	RefreshTokenHash *string   "json:\"refreshTokenHash,omitempty\" graphql:\"refreshTokenHash\""
+	CreatedAt        time.Time "json:\"createdAt\" graphql:\"createdAt\""
+	ExpiresAt        time.Time "json:\"expiresAt\" graphql:\"expiresAt\""
+}
+
+func (t *GetPersonalAccessTokens_AuthRefreshTokens) GetRefreshTokenHash() *string {
+	if t == nil {
+		t = &GetPersonalAccessTokens_AuthRefreshTokens{}
+	}
+	return t.RefreshTokenHash
+}
+func (t *GetPersonalAccessTokens_AuthRefreshTokens) GetCreatedAt() *time.Time {
+	if t == nil {
+		t = &GetPersonalAccessTokens_AuthRefreshTokens{}
+	}
+	return &t.CreatedAt
+}
+func (t *GetPersonalAccessTokens_AuthRefreshTokens) GetExpiresAt() *time.Time {
+	if t == nil {
+		t = &GetPersonalAccessTokens_AuthRefreshTokens{}
+	}
+	return &t.ExpiresAt
+}
+
 type GetSecrets_AppSecrets struct {
 	Name  string "json:\"name\" graphql:\"name\""
 	Value string "json:\"value\" graphql:\"value\""
@@ -627,6 +653,17 @@ func (t *DeleteRefreshToken) GetDeleteAuthRefreshTokens() *DeleteRefreshToken_De
 	return t.DeleteAuthRefreshTokens
 }
 
+type GetPersonalAccessTokens struct {
+	AuthRefreshTokens []*GetPersonalAccessTokens_AuthRefreshTokens "json:\"authRefreshTokens\" graphql:\"authRefreshTokens\""
+}
+
+func (t *GetPersonalAccessTokens) GetAuthRefreshTokens() []*GetPersonalAccessTokens_AuthRefreshTokens {
+	if t == nil {
+		t = &GetPersonalAccessTokens{}
+	}
+	return t.AuthRefreshTokens
+}
+
 type GetSecrets struct {
 	AppSecrets []*GetSecrets_AppSecrets "json:\"appSecrets\" graphql:\"appSecrets\""
 }
@@ -744,7 +781,7 @@ const DeleteRefreshTokenDocument = `mutation DeleteRefreshToken ($where: authRef
 	deleteAuthRefreshTokens(where: $where) {
 		affected_rows
 		returning {
-			refreshToken
+			refreshTokenHash
 		}
 	}
 }
@@ -763,6 +800,28 @@ func (c *Client) DeleteRefreshToken(ctx context.Context, where AuthRefreshTokens
 	return &res, nil
 }
 
+const GetPersonalAccessTokensDocument = `query GetPersonalAccessTokens ($where: authRefreshTokens_bool_exp!) {
+	authRefreshTokens(where: $where, order_by: {createdAt:asc}) {
+		refreshTokenHash
+		createdAt
+		expiresAt
+	}
+}
+`
+
+func (c *Client) GetPersonalAccessTokens(ctx context.Context, where AuthRefreshTokensBoolExp, interceptors ...clientv2.RequestInterceptor) (*GetPersonalAccessTokens, error) {
+	vars := map[string]interface{}{
+		"where": where,
+	}
+
+	var res GetPersonalAccessTokens
+	if err := c.Client.Post(ctx, "GetPersonalAccessTokens", GetPersonalAccessTokensDocument, &res, vars, interceptors...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const GetSecretsDocument = `query GetSecrets ($appID: uuid!) {
 	appSecrets(appID: $appID) {
 		name
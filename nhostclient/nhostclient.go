@@ -26,12 +26,20 @@ type Client struct {
 func New(domain string) *Client {
 	return &Client{
 		baseURL: fmt.Sprintf("https://%s/v1/auth", domain),
-		client:  &http.Client{}, //nolint:exhaustruct
+		client:  newHTTPClient(),
 		Client: graphql.NewClient(
-			&http.Client{}, //nolint:exhaustruct
+			newHTTPClient(),
 			fmt.Sprintf("https://%s/v1/graphql", domain),
 			&clientv2.Options{}, //nolint:exhaustruct
 		),
 		retryer: NewBasicRetryer(retryerMaxAttempts, retryerBaseDelay),
 	}
 }
+
+// newHTTPClient returns a client that returns *NetworkError when the API
+// can't be reached.
+func newHTTPClient() *http.Client {
+	return &http.Client{ //nolint:exhaustruct
+		Transport: networkTransport{transport: http.DefaultTransport},
+	}
+}
@@ -0,0 +1,277 @@
+/*
+This package runs GraphQL operations as different roles and checks the outcome
+against the expectations in a spec file to detect misconfigured permissions.
+*/
+package permissions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nhost/cli/hasura"
+	"gopkg.in/yaml.v3"
+)
+
+const adminRole = "admin"
+
+type Expect struct {
+	// Allowed is true if the operation must succeed and false if hasura must
+	// return an error. Defaults to true.
+	Allowed *bool `yaml:"allowed"`
+	// Rows maps a path in the response data to the expected number of elements.
+	Rows map[string]int `yaml:"rows"`
+	// Nulls lists paths in the response data that must be null.
+	Nulls []string `yaml:"nulls"`
+	// NotNulls lists paths in the response data that must not be null.
+	NotNulls []string `yaml:"notNulls"`
+}
+
+func (e Expect) allowed() bool {
+	return e.Allowed == nil || *e.Allowed
+}
+
+type Case struct {
+	// Name identifies the case in the matrix, defaults to the role.
+	Name    string            `yaml:"name"`
+	Role    string            `yaml:"role"`
+	Session map[string]string `yaml:"session"`
+	Expect  Expect            `yaml:"expect"`
+}
+
+func (c Case) Label() string {
+	switch {
+	case c.Name != "":
+		return c.Name
+	case c.Role != "":
+		return c.Role
+	default:
+		return adminRole
+	}
+}
+
+// SessionVariables returns the session variables of the case with the
+// x-hasura- prefix added if missing.
+func (c Case) SessionVariables() map[string]string {
+	vars := make(map[string]string, len(c.Session))
+	for k, v := range c.Session {
+		k = strings.ToLower(k)
+		if !strings.HasPrefix(k, "x-hasura-") {
+			k = "x-hasura-" + k
+		}
+		vars[k] = v
+	}
+	return vars
+}
+
+type Operation struct {
+	Name      string         `yaml:"name"`
+	Query     string         `yaml:"query"`
+	Variables map[string]any `yaml:"variables"`
+	Cases     []Case         `yaml:"roles"`
+}
+
+type Spec struct {
+	Operations []Operation `yaml:"operations"`
+}
+
+func LoadSpec(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spec: %w", err)
+	}
+	defer f.Close()
+
+	var spec Spec
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	operations := make(map[string]struct{}, len(spec.Operations))
+	for i, op := range spec.Operations {
+		if op.Name == "" {
+			return nil, fmt.Errorf("operation %d is missing a name", i) //nolint:goerr113
+		}
+		if op.Query == "" {
+			return nil, fmt.Errorf("operation %s is missing a query", op.Name) //nolint:goerr113
+		}
+		if len(op.Cases) == 0 {
+			return nil, fmt.Errorf("operation %s has no roles to test", op.Name) //nolint:goerr113
+		}
+		if _, ok := operations[op.Name]; ok {
+			return nil, fmt.Errorf("operation %s is duplicated", op.Name) //nolint:goerr113
+		}
+		operations[op.Name] = struct{}{}
+
+		// cases are identified by their label in the matrix
+		labels := make(map[string]struct{}, len(op.Cases))
+		for _, c := range op.Cases {
+			if _, ok := labels[c.Label()]; ok {
+				return nil, fmt.Errorf( //nolint:goerr113
+					"operation %s has more than one case named %s, set a unique name for each",
+					op.Name, c.Label(),
+				)
+			}
+			labels[c.Label()] = struct{}{}
+		}
+	}
+
+	return &spec, nil
+}
+
+type Result struct {
+	Operation string
+	Case      string
+	// Mismatches lists the expectations that weren't met, empty if the case passed.
+	Mismatches []string
+}
+
+func (r Result) Passed() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Run executes every case in the spec against the GraphQL endpoint at url
+// impersonating its role and session variables with the admin secret.
+func Run(ctx context.Context, url, adminSecret string, spec *Spec) ([]Result, error) {
+	results := make([]Result, 0, len(spec.Operations))
+	for _, op := range spec.Operations {
+		for _, c := range op.Cases {
+			client := hasura.NewClient(
+				url, hasura.SessionHeaders(adminSecret, c.Role, c.SessionVariables()),
+			)
+			resp, err := client.Do(ctx, hasura.Request{
+				Query:         op.Query,
+				Variables:     op.Variables,
+				OperationName: "",
+			})
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to run operation %s as %s: %w", op.Name, c.Label(), err,
+				)
+			}
+
+			results = append(results, Result{
+				Operation:  op.Name,
+				Case:       c.Label(),
+				Mismatches: check(c.Expect, resp),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+func check(expect Expect, resp *hasura.Response) []string {
+	mismatches := make([]string, 0)
+
+	if len(resp.Errors) > 0 {
+		if expect.allowed() {
+			mismatches = append(mismatches, "expected allowed, got denied: "+
+				(&hasura.ResponseError{Errors: resp.Errors}).Error())
+		}
+		return mismatches
+	}
+
+	if !expect.allowed() {
+		return append(mismatches, "expected denied, got allowed")
+	}
+
+	var data any
+	if len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return append(mismatches, fmt.Sprintf("failed to parse response data: %s", err))
+		}
+	}
+
+	paths := make([]string, 0, len(expect.Rows))
+	for path := range expect.Rows {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		expected := expect.Rows[path]
+		values, err := lookup(data, path)
+		if err != nil {
+			mismatches = append(mismatches, err.Error())
+			continue
+		}
+		for _, v := range values {
+			rows, ok := v.([]any)
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s: expected a list", path))
+				continue
+			}
+			if len(rows) != expected {
+				mismatches = append(
+					mismatches,
+					fmt.Sprintf("%s: expected %d rows, got %d", path, expected, len(rows)),
+				)
+			}
+		}
+	}
+
+	mismatches = append(mismatches, checkNulls(data, expect.Nulls, true)...)
+	mismatches = append(mismatches, checkNulls(data, expect.NotNulls, false)...)
+
+	return mismatches
+}
+
+func checkNulls(data any, paths []string, null bool) []string {
+	mismatches := make([]string, 0)
+	for _, path := range paths {
+		values, err := lookup(data, path)
+		if err != nil {
+			mismatches = append(mismatches, err.Error())
+			continue
+		}
+		for _, v := range values {
+			switch {
+			case null && v != nil:
+				mismatches = append(mismatches, fmt.Sprintf("%s: expected null, got %v", path, v))
+			case !null && v == nil:
+				mismatches = append(mismatches, fmt.Sprintf("%s: expected not null", path))
+			}
+		}
+	}
+	return mismatches
+}
+
+// lookup returns the values found at path. Path elements are separated by
+// dots, numeric elements index lists and * matches every element of a list.
+func lookup(data any, path string) ([]any, error) {
+	values := []any{data}
+	for _, key := range strings.Split(path, ".") {
+		next := make([]any, 0, len(values))
+		for _, v := range values {
+			switch v := v.(type) {
+			case map[string]any:
+				child, ok := v[key]
+				if !ok {
+					return nil, fmt.Errorf("%s: field %s not found", path, key) //nolint:goerr113
+				}
+				next = append(next, child)
+			case []any:
+				if key == "*" {
+					next = append(next, v...)
+					continue
+				}
+				i, err := strconv.Atoi(key)
+				if err != nil || i < 0 || i >= len(v) {
+					return nil, fmt.Errorf("%s: index %s out of range", path, key) //nolint:goerr113
+				}
+				next = append(next, v[i])
+			default:
+				return nil, fmt.Errorf("%s: can't access %s", path, key) //nolint:goerr113
+			}
+		}
+		values = next
+	}
+	return values, nil
+}
@@ -0,0 +1,170 @@
+package permissions_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/permissions"
+)
+
+const spec = `
+operations:
+  - name: list todos
+    query: |
+      query { todos { id secret } }
+    roles:
+      - role: user
+        session:
+          user-id: "1"
+        expect:
+          rows:
+            todos: 2
+          nulls:
+            - todos.*.secret
+      - name: wrong rows
+        role: user
+        session:
+          user-id: "1"
+        expect:
+          rows:
+            todos: 1
+          notNulls:
+            - todos.0.secret
+      - role: public
+        expect:
+          allowed: false
+      - name: public allowed
+        role: public
+`
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Hasura-Admin-Secret") != "secret" {
+			t.Errorf("missing admin secret")
+		}
+
+		switch r.Header.Get("X-Hasura-Role") {
+		case "user":
+			if r.Header.Get("X-Hasura-User-Id") != "1" {
+				t.Errorf("missing user id")
+			}
+			_, _ = w.Write(
+				[]byte(`{"data":{"todos":[{"id":1,"secret":null},{"id":2,"secret":null}]}}`),
+			)
+		default:
+			_, _ = w.Write(
+				[]byte(`{"errors":[{"message":"field 'todos' not found in type: 'query_root'"}]}`),
+			)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := permissions.LoadSpec(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := permissions.Run(context.Background(), srv.URL, "secret", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []permissions.Result{
+		{
+			Operation:  "list todos",
+			Case:       "user",
+			Mismatches: []string{},
+		},
+		{
+			Operation: "list todos",
+			Case:      "wrong rows",
+			Mismatches: []string{
+				"todos: expected 1 rows, got 2",
+				"todos.0.secret: expected not null",
+			},
+		},
+		{
+			Operation:  "list todos",
+			Case:       "public",
+			Mismatches: []string{},
+		},
+		{
+			Operation: "list todos",
+			Case:      "public allowed",
+			Mismatches: []string{
+				"expected allowed, got denied: field 'todos' not found in type: 'query_root'",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadSpecInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "no roles",
+			spec: "operations:\n  - name: no roles\n    query: query { a }\n",
+		},
+		{
+			name: "duplicated case label",
+			spec: `operations:
+  - name: list
+    query: query { a }
+    roles:
+      - role: user
+      - role: user
+        session:
+          user-id: "1"
+`,
+		},
+		{
+			name: "duplicated operation",
+			spec: `operations:
+  - name: list
+    query: query { a }
+    roles:
+      - role: user
+  - name: list
+    query: query { b }
+    roles:
+      - role: user
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "spec.yaml")
+			if err := os.WriteFile(path, []byte(tc.spec), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := permissions.LoadSpec(path); err == nil {
+				t.Error("expected an error loading the spec")
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package seeds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// namespace used to derive stable file ids from the bucket and path of a file
+// so seeds can reference them.
+var storageNamespace = uuid.MustParse("5ef6a8f1-3b2c-4c8e-9a43-7d1c2b9e6f0a") //nolint:gochecknoglobals
+
+// StorageFileID returns the id a file under the storage seeds folder will be uploaded with.
+func StorageFileID(bucket, path string) string {
+	return uuid.NewSHA1(storageNamespace, []byte(bucket+"/"+path)).String()
+}
+
+type StorageFile struct {
+	ID       string
+	Bucket   string
+	Name     string
+	Filepath string
+}
+
+// ListStorageFiles returns the files under folder. First level directories are
+// treated as bucket ids and files are named after their path inside the bucket.
+func ListStorageFiles(folder string) ([]StorageFile, error) {
+	buckets, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage seeds folder: %w", err)
+	}
+
+	files := make([]StorageFile, 0)
+	for _, bucket := range buckets {
+		if !bucket.IsDir() {
+			continue
+		}
+
+		bucketFolder := filepath.Join(folder, bucket.Name())
+		if err := filepath.WalkDir(
+			bucketFolder,
+			func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+					return nil
+				}
+
+				rel, err := filepath.Rel(bucketFolder, path)
+				if err != nil {
+					return fmt.Errorf("failed to get relative path: %w", err)
+				}
+				name := filepath.ToSlash(rel)
+
+				files = append(files, StorageFile{
+					ID:       StorageFileID(bucket.Name(), name),
+					Bucket:   bucket.Name(),
+					Name:     name,
+					Filepath: path,
+				})
+				return nil
+			},
+		); err != nil {
+			return nil, fmt.Errorf("failed to list files in bucket %s: %w", bucket.Name(), err)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Bucket != files[j].Bucket {
+			return files[i].Bucket < files[j].Bucket
+		}
+		return files[i].Name < files[j].Name
+	})
+
+	return files, nil
+}
+
+type Storage struct {
+	baseURL     string
+	adminSecret string
+	client      *http.Client
+}
+
+func NewStorage(baseURL, adminSecret string) *Storage {
+	return &Storage{
+		baseURL:     baseURL,
+		adminSecret: adminSecret,
+		client:      &http.Client{}, //nolint:exhaustruct
+	}
+}
+
+func (s *Storage) Exists(ctx context.Context, id string) (bool, error) {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodHead, fmt.Sprintf("%s/files/%s", s.baseURL, id), nil,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Hasura-Admin-Secret", s.adminSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode) //nolint:goerr113
+	}
+}
+
+func uploadBody(file StorageFile) (*bytes.Buffer, string, error) {
+	f, err := os.Open(file.Filepath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("bucket-id", file.Bucket); err != nil {
+		return nil, "", fmt.Errorf("failed to write bucket-id: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]string{
+		"id":   file.ID,
+		"name": file.Name,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := w.WriteField("metadata[]", string(metadata)); err != nil {
+		return nil, "", fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	part, err := w.CreateFormFile("file[]", filepath.Base(file.Filepath))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, "", fmt.Errorf("failed to copy file: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	return body, w.FormDataContentType(), nil
+}
+
+func (s *Storage) Upload(ctx context.Context, file StorageFile) error {
+	body, contentType, err := uploadBody(file)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, s.baseURL+"/files", body,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Hasura-Admin-Secret", s.adminSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, message: %s", resp.StatusCode, string(b)) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// Apply uploads the files under folder that don't exist yet and returns them.
+func (s *Storage) Apply(ctx context.Context, folder string) ([]StorageFile, error) {
+	files, err := ListStorageFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded := make([]StorageFile, 0, len(files))
+	for _, file := range files {
+		exists, err := s.Exists(ctx, file.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s/%s exists: %w", file.Bucket, file.Name, err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := s.Upload(ctx, file); err != nil {
+			return nil, fmt.Errorf("failed to upload %s/%s: %w", file.Bucket, file.Name, err)
+		}
+		uploaded = append(uploaded, file)
+	}
+
+	return uploaded, nil
+}
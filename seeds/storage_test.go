@@ -0,0 +1,151 @@
+package seeds_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/cli/seeds"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListStorageFiles(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	writeFile(t, filepath.Join(folder, "default", "logo.png"), "logo")
+	writeFile(t, filepath.Join(folder, "default", "avatars", "jane.png"), "jane")
+	writeFile(t, filepath.Join(folder, "default", ".gitkeep"), "")
+	writeFile(t, filepath.Join(folder, "README.md"), "ignored")
+
+	got, err := seeds.ListStorageFiles(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []seeds.StorageFile{
+		{
+			ID:       seeds.StorageFileID("default", "avatars/jane.png"),
+			Bucket:   "default",
+			Name:     "avatars/jane.png",
+			Filepath: filepath.Join(folder, "default", "avatars", "jane.png"),
+		},
+		{
+			ID:       seeds.StorageFileID("default", "logo.png"),
+			Bucket:   "default",
+			Name:     "logo.png",
+			Filepath: filepath.Join(folder, "default", "logo.png"),
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestStorageFileIDIsStable(t *testing.T) {
+	t.Parallel()
+
+	if seeds.StorageFileID("default", "logo.png") != seeds.StorageFileID("default", "logo.png") {
+		t.Error("expected the same id for the same bucket and path")
+	}
+
+	if seeds.StorageFileID("default", "logo.png") == seeds.StorageFileID("other", "logo.png") {
+		t.Error("expected different ids for different buckets")
+	}
+}
+
+func TestStorageApply(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	writeFile(t, filepath.Join(folder, "default", "existing.txt"), "existing")
+	writeFile(t, filepath.Join(folder, "default", "new.txt"), "new")
+
+	existingID := seeds.StorageFileID("default", "existing.txt")
+
+	var mu sync.Mutex
+	uploads := make([]map[string]string, 0)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Hasura-Admin-Secret") != "adminSecret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v1/files/"+existingID:
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/files":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("failed to parse multipart form: %v", err)
+			}
+
+			var metadata map[string]string
+			if err := json.Unmarshal([]byte(r.FormValue("metadata[]")), &metadata); err != nil {
+				t.Errorf("failed to unmarshal metadata: %v", err)
+			}
+
+			f, _, err := r.FormFile("file[]")
+			if err != nil {
+				t.Errorf("failed to get file: %v", err)
+			}
+			b, _ := io.ReadAll(f)
+
+			mu.Lock()
+			uploads = append(uploads, map[string]string{
+				"bucket":  r.FormValue("bucket-id"),
+				"id":      metadata["id"],
+				"name":    metadata["name"],
+				"content": string(b),
+			})
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	st := seeds.NewStorage(ts.URL+"/v1", "adminSecret")
+	uploaded, err := st.Apply(context.Background(), folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(uploaded) != 1 || uploaded[0].Name != "new.txt" {
+		t.Errorf("expected only new.txt to be uploaded, got %v", uploaded)
+	}
+
+	expected := []map[string]string{
+		{
+			"bucket":  "default",
+			"id":      seeds.StorageFileID("default", "new.txt"),
+			"name":    "new.txt",
+			"content": "new",
+		},
+	}
+	if diff := cmp.Diff(expected, uploads); diff != "" {
+		t.Error(diff)
+	}
+}
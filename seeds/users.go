@@ -0,0 +1,332 @@
+package seeds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/hasura"
+	"github.com/nhost/cli/nhostclient"
+	"gopkg.in/yaml.v3"
+)
+
+//nolint:tagliatelle
+type User struct {
+	Email         string         `json:"email"         yaml:"email"`
+	Password      string         `json:"password"      yaml:"password"`
+	DisplayName   string         `json:"displayName"   yaml:"displayName"`
+	Locale        string         `json:"locale"        yaml:"locale"`
+	DefaultRole   string         `json:"defaultRole"   yaml:"defaultRole"`
+	AllowedRoles  []string       `json:"allowedRoles"  yaml:"allowedRoles"`
+	Metadata      map[string]any `json:"metadata"      yaml:"metadata"`
+	EmailVerified bool           `json:"emailVerified" yaml:"emailVerified"`
+}
+
+// WithDefaults returns a copy of the user with the roles missing from the
+// fixture set to the ones configured in auth.user.roles.
+func (u User) WithDefaults(roles *model.ConfigAuthUserRoles) User {
+	if u.DefaultRole == "" && roles.GetDefault() != nil {
+		u.DefaultRole = *roles.GetDefault()
+	}
+	if len(u.AllowedRoles) == 0 {
+		u.AllowedRoles = roles.GetAllowed()
+	}
+	if u.DisplayName == "" {
+		u.DisplayName = u.Email
+	}
+	return u
+}
+
+// Validate checks the user is complete and that its roles are part of the
+// roles configured in auth.user.roles.
+func (u User) Validate(roles *model.ConfigAuthUserRoles) error {
+	if u.Email == "" {
+		return fmt.Errorf("email is required") //nolint:goerr113
+	}
+	if u.Password == "" {
+		return fmt.Errorf("password is required for %s", u.Email) //nolint:goerr113
+	}
+
+	configured := map[string]struct{}{}
+	for _, role := range roles.GetAllowed() {
+		configured[role] = struct{}{}
+	}
+	if roles.GetDefault() != nil {
+		configured[*roles.GetDefault()] = struct{}{}
+	}
+
+	for _, role := range append([]string{u.DefaultRole}, u.AllowedRoles...) {
+		if _, ok := configured[role]; !ok {
+			return fmt.Errorf( //nolint:goerr113
+				"role %s of %s is not in auth.user.roles", role, u.Email,
+			)
+		}
+	}
+
+	for _, role := range u.AllowedRoles {
+		if role == u.DefaultRole {
+			return nil
+		}
+	}
+
+	return fmt.Errorf( //nolint:goerr113
+		"default role %s is not in the allowed roles of %s", u.DefaultRole, u.Email,
+	)
+}
+
+// LoadUsers reads the user fixtures file.
+func LoadUsers(path string) ([]User, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users file: %w", err)
+	}
+
+	var users []User
+	if err := yaml.Unmarshal(b, &users); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal users file: %w", err)
+	}
+
+	return users, nil
+}
+
+type UserRole struct {
+	Role string `json:"role"`
+}
+
+type UserInfo struct {
+	ID            string     `json:"id"`
+	Email         string     `json:"email"`
+	DisplayName   string     `json:"displayName"`
+	DefaultRole   string     `json:"defaultRole"`
+	Roles         []UserRole `json:"roles"`
+	EmailVerified bool       `json:"emailVerified"`
+	Disabled      bool       `json:"disabled"`
+}
+
+func (u UserInfo) AllowedRoles() []string {
+	roles := make([]string, len(u.Roles))
+	for i, r := range u.Roles {
+		roles[i] = r.Role
+	}
+	return roles
+}
+
+const userFields = `id email displayName defaultRole emailVerified disabled roles { role }`
+
+type Users struct {
+	authURL string
+	gql     *hasura.Client
+	client  *http.Client
+	retryer nhostclient.BasicRetryer
+}
+
+func NewUsers(authURL, graphqlURL, adminSecret string) *Users {
+	return &Users{
+		authURL: authURL,
+		gql:     hasura.NewClient(graphqlURL, hasura.AdminHeaders(adminSecret)),
+		client:  &http.Client{}, //nolint:exhaustruct
+		retryer: nhostclient.NewBasicRetryer(1, 1),
+	}
+}
+
+func (u *Users) List(ctx context.Context) ([]UserInfo, error) {
+	var resp struct {
+		Users []UserInfo `json:"users"`
+	}
+	if err := u.gql.Query(
+		ctx,
+		`query { users(order_by: {email: asc}) { `+userFields+` } }`,
+		nil,
+		&resp,
+	); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return resp.Users, nil
+}
+
+// Get returns the user with the given email or nil if it doesn't exist.
+func (u *Users) Get(ctx context.Context, email string) (*UserInfo, error) {
+	var resp struct {
+		Users []UserInfo `json:"users"`
+	}
+	if err := u.gql.Query(
+		ctx,
+		`query($email: citext!) { users(where: {email: {_eq: $email}}) { `+userFields+` } }`,
+		map[string]any{"email": email},
+		&resp,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if len(resp.Users) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &resp.Users[0], nil
+}
+
+type signUpOptions struct {
+	DisplayName string         `json:"displayName,omitempty"`
+	Locale      string         `json:"locale,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+type signUpRequest struct {
+	Email    string        `json:"email"`
+	Password string        `json:"password"`
+	Options  signUpOptions `json:"options"`
+}
+
+func (u *Users) signUp(ctx context.Context, user User) error {
+	var resp any
+	if err := nhostclient.MakeJSONRequest(
+		ctx,
+		u.client,
+		u.authURL+"/signup/email-password",
+		http.MethodPost,
+		signUpRequest{
+			Email:    user.Email,
+			Password: user.Password,
+			Options: signUpOptions{
+				DisplayName: user.DisplayName,
+				Locale:      user.Locale,
+				Metadata:    user.Metadata,
+			},
+		},
+		http.Header{},
+		&resp,
+		func(resp *http.Response) error {
+			if resp.StatusCode != http.StatusOK {
+				b, _ := io.ReadAll(resp.Body)
+				return fmt.Errorf("unexpected status code: %d, message: %s", resp.StatusCode, string(b)) //nolint:goerr113
+			}
+			return nil
+		},
+		u.retryer,
+	); err != nil {
+		return fmt.Errorf("failed to sign up: %w", err)
+	}
+
+	return nil
+}
+
+func (u *Users) setRoles(ctx context.Context, id string, user User) error {
+	roles := make([]map[string]any, len(user.AllowedRoles))
+	for i, role := range user.AllowedRoles {
+		roles[i] = map[string]any{"userId": id, "role": role}
+	}
+
+	if err := u.gql.Query(
+		ctx,
+		`mutation($id: uuid!, $set: users_set_input!, $roles: [authUserRoles_insert_input!]!) {
+			updateUser(pk_columns: {id: $id}, _set: $set) { id }
+			deleteAuthUserRoles(where: {userId: {_eq: $id}}) { affected_rows }
+			insertAuthUserRoles(objects: $roles) { affected_rows }
+		}`,
+		map[string]any{
+			"id": id,
+			"set": map[string]any{
+				"defaultRole":   user.DefaultRole,
+				"emailVerified": user.EmailVerified,
+				"disabled":      false,
+			},
+			"roles": roles,
+		},
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to set roles: %w", err)
+	}
+
+	return nil
+}
+
+// Create signs up the user through hasura-auth and then sets its roles and
+// email verification status.
+func (u *Users) Create(
+	ctx context.Context,
+	user User,
+	roles *model.ConfigAuthUserRoles,
+) (*UserInfo, error) {
+	if err := user.Validate(roles); err != nil {
+		return nil, err
+	}
+
+	if err := u.signUp(ctx, user); err != nil {
+		return nil, err
+	}
+
+	info, err := u.Get(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("user %s not found after sign up", user.Email) //nolint:goerr113
+	}
+
+	if err := u.setRoles(ctx, info.ID, user); err != nil {
+		return nil, err
+	}
+
+	return u.Get(ctx, user.Email)
+}
+
+func (u *Users) Delete(ctx context.Context, id string) error {
+	var resp struct {
+		DeleteUser *struct {
+			ID string `json:"id"`
+		} `json:"deleteUser"`
+	}
+	if err := u.gql.Query(
+		ctx,
+		`mutation($id: uuid!) { deleteUser(id: $id) { id } }`,
+		map[string]any{"id": id},
+		&resp,
+	); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if resp.DeleteUser == nil {
+		return fmt.Errorf("user %s not found", id) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// Apply creates the users that don't exist yet and returns them.
+func (u *Users) Apply(
+	ctx context.Context,
+	users []User,
+	roles *model.ConfigAuthUserRoles,
+) ([]UserInfo, error) {
+	// validate every fixture before creating any user
+	users = append([]User(nil), users...)
+	for i, user := range users {
+		users[i] = user.WithDefaults(roles)
+		if err := users[i].Validate(roles); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]UserInfo, 0, len(users))
+	for _, user := range users {
+		existing, err := u.Get(ctx, user.Email)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		info, err := u.Create(ctx, user, roles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", user.Email, err)
+		}
+		created = append(created, *info)
+	}
+
+	return created, nil
+}
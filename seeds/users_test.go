@@ -0,0 +1,163 @@
+package seeds_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/be/services/mimir/model"
+	"github.com/nhost/cli/seeds"
+)
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func TestLoadUsers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "auth-seeds.yaml")
+	writeFile(t, path, `
+- email: admin@example.com
+  password: password123
+  displayName: Admin
+  defaultRole: admin
+  allowedRoles: [user, me, admin]
+  emailVerified: true
+  metadata:
+    team: backend
+- email: user@example.com
+  password: password123
+`)
+
+	got, err := seeds.LoadUsers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []seeds.User{
+		{
+			Email:         "admin@example.com",
+			Password:      "password123",
+			DisplayName:   "Admin",
+			Locale:        "",
+			DefaultRole:   "admin",
+			AllowedRoles:  []string{"user", "me", "admin"},
+			Metadata:      map[string]any{"team": "backend"},
+			EmailVerified: true,
+		},
+		{
+			Email:         "user@example.com",
+			Password:      "password123",
+			DisplayName:   "",
+			Locale:        "",
+			DefaultRole:   "",
+			AllowedRoles:  nil,
+			Metadata:      nil,
+			EmailVerified: false,
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUserWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	roles := &model.ConfigAuthUserRoles{
+		Default: ptr("user"),
+		Allowed: []string{"user", "me"},
+	}
+
+	cases := []struct {
+		name        string
+		user        seeds.User
+		expected    seeds.User
+		expectedErr bool
+	}{
+		{
+			name: "defaults from config",
+			user: seeds.User{ //nolint:exhaustruct
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			expected: seeds.User{ //nolint:exhaustruct
+				Email:        "user@example.com",
+				Password:     "password123",
+				DisplayName:  "user@example.com",
+				DefaultRole:  "user",
+				AllowedRoles: []string{"user", "me"},
+			},
+		},
+		{
+			name: "roles subset of config",
+			user: seeds.User{ //nolint:exhaustruct
+				Email:        "me@example.com",
+				Password:     "password123",
+				DisplayName:  "Me",
+				DefaultRole:  "me",
+				AllowedRoles: []string{"me"},
+			},
+			expected: seeds.User{ //nolint:exhaustruct
+				Email:        "me@example.com",
+				Password:     "password123",
+				DisplayName:  "Me",
+				DefaultRole:  "me",
+				AllowedRoles: []string{"me"},
+			},
+		},
+		{
+			name: "allowed role not in config",
+			user: seeds.User{ //nolint:exhaustruct
+				Email:        "admin@example.com",
+				Password:     "password123",
+				DisplayName:  "Admin",
+				DefaultRole:  "user",
+				AllowedRoles: []string{"user", "admin"},
+			},
+			expected: seeds.User{ //nolint:exhaustruct
+				Email:        "admin@example.com",
+				Password:     "password123",
+				DisplayName:  "Admin",
+				DefaultRole:  "user",
+				AllowedRoles: []string{"user", "admin"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "default role not allowed",
+			user: seeds.User{ //nolint:exhaustruct
+				Email:       "admin@example.com",
+				Password:    "password123",
+				DefaultRole: "admin",
+			},
+			expected: seeds.User{ //nolint:exhaustruct
+				Email:        "admin@example.com",
+				Password:     "password123",
+				DisplayName:  "admin@example.com",
+				DefaultRole:  "admin",
+				AllowedRoles: []string{"user", "me"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.user.WithDefaults(roles)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+
+			err := got.Validate(roles)
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}